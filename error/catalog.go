@@ -0,0 +1,288 @@
+package error
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	LanguageEnglish    = "en"
+	LanguageIndonesian = "id"
+)
+
+// Catalog holds message templates keyed by language and message key.
+// Templates may contain named placeholders such as "{amount}".
+type Catalog struct {
+	mu              sync.RWMutex
+	defaultLanguage string
+	messages        map[string]map[string]string
+}
+
+// DefaultCatalog is used by Localize and NewLocalizedError. It already knows
+// the HTTP status messages in English and Indonesian under the key "http.<code>".
+var DefaultCatalog = newDefaultCatalog()
+
+func NewCatalog(defaultLanguage string) *Catalog {
+	return &Catalog{
+		defaultLanguage: normalizeLanguage(defaultLanguage),
+		messages:        map[string]map[string]string{},
+	}
+}
+
+func (c *Catalog) DefaultLanguage() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.defaultLanguage
+}
+
+// Add registers a single message template.
+func (c *Catalog) Add(language, key, message string) {
+	c.AddMessages(language, map[string]string{key: message})
+}
+
+// AddMessages registers message templates for one language.
+func (c *Catalog) AddMessages(language string, messages map[string]string) {
+	language = normalizeLanguage(language)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.messages[language] == nil {
+		c.messages[language] = map[string]string{}
+	}
+	for k, v := range messages {
+		c.messages[language][k] = v
+	}
+}
+
+// LoadFile loads a JSON or YAML file of key/message pairs. The file name without
+// extension is the language, e.g. "locales/id.yaml".
+func (c *Catalog) LoadFile(filename string) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	return c.load(filepath.Base(filename), data)
+}
+
+// LoadFS loads every file matching pattern from fsys, e.g. an embed.FS.
+func (c *Catalog) LoadFS(fsys fs.FS, pattern string) error {
+	matches, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	for _, name := range matches {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err = c.load(path.Base(name), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) load(name string, data []byte) error {
+	ext := path.Ext(name)
+	messages := map[string]string{}
+	switch strings.ToLower(ext) {
+	case ".json":
+		if err := json.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("catalog %s: %w", name, err)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &messages); err != nil {
+			return fmt.Errorf("catalog %s: %w", name, err)
+		}
+	default:
+		return fmt.Errorf("catalog %s: unsupported file type", name)
+	}
+	c.AddMessages(strings.TrimSuffix(name, ext), messages)
+	return nil
+}
+
+// Message renders key in language, falling back to the base language ("id" for "id-ID")
+// and then the default language. ok is false when no template is found.
+func (c *Catalog) Message(key, language string, params map[string]interface{}) (message string, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, lang := range c.candidates(language) {
+		if m, found := c.messages[lang][key]; found {
+			return renderMessage(m, params), true
+		}
+	}
+	return "", false
+}
+
+// Localize renders the ApplicationError found in err's chain in language.
+// Errors without a message key are looked up by their code under "http.<code>"
+// when they still carry the default status message.
+func (c *Catalog) Localize(err error, language string) string {
+	if err == nil {
+		return ""
+	}
+	appErr, ok := As(err)
+	if !ok {
+		return err.Error()
+	}
+	if appErr.MessageKey != "" {
+		if m, ok := c.Message(appErr.MessageKey, language, appErr.MessageParams); ok {
+			return m
+		}
+		return appErr.Message
+	}
+	if appErr.Message == StatusMessage(appErr.ErrorCode) {
+		if m, ok := c.Message(statusMessageKey(appErr.ErrorCode), language, nil); ok {
+			return m
+		}
+	}
+	return appErr.Message
+}
+
+// MatchLanguage picks the best supported language from an Accept-Language header.
+func (c *Catalog) MatchLanguage(acceptLanguage string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, lang := range ParseAcceptLanguage(acceptLanguage) {
+		if _, ok := c.messages[lang]; ok {
+			return lang
+		}
+		if base := baseLanguage(lang); base != lang {
+			if _, ok := c.messages[base]; ok {
+				return base
+			}
+		}
+	}
+	return c.defaultLanguage
+}
+
+func (c *Catalog) candidates(language string) []string {
+	language = normalizeLanguage(language)
+	return []string{language, baseLanguage(language), c.defaultLanguage}
+}
+
+// Localize renders err with DefaultCatalog.
+func Localize(err error, language string) string {
+	return DefaultCatalog.Localize(err, language)
+}
+
+// NewLocalizedError creates an ApplicationError whose message is rendered from key.
+// Message is filled in the default language so Error() stays readable.
+func NewLocalizedError(code int, status, key string, params map[string]interface{}) error {
+	err := &ApplicationError{
+		ErrorCode: code,
+		Status:    status,
+		Message:   StatusMessage(code),
+		stack:     callers(),
+	}
+	return err.WithMessageKey(key, params)
+}
+
+// WithMessageKey sets the catalog key and params used to render the message.
+func (e *ApplicationError) WithMessageKey(key string, params map[string]interface{}) *ApplicationError {
+	e.MessageKey = key
+	e.MessageParams = params
+	if m, ok := DefaultCatalog.Message(key, DefaultCatalog.DefaultLanguage(), params); ok {
+		e.Message = m
+	}
+	return e
+}
+
+// ParseAcceptLanguage returns the languages of an Accept-Language header ordered by quality.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang string
+		q    float64
+	}
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		q := 1.0
+		if i := strings.Index(part, ";"); i >= 0 {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(part[i+1:]), "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+			part = part[:i]
+		}
+		if part == "*" || q <= 0 {
+			continue
+		}
+		langs = append(langs, weighted{normalizeLanguage(part), q})
+	}
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+
+	result := make([]string, 0, len(langs))
+	for _, l := range langs {
+		result = append(result, l.lang)
+	}
+	return result
+}
+
+func normalizeLanguage(language string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(language), "_", "-"))
+}
+
+func baseLanguage(language string) string {
+	if i := strings.Index(language, "-"); i > 0 {
+		return language[:i]
+	}
+	return language
+}
+
+func renderMessage(message string, params map[string]interface{}) string {
+	if len(params) == 0 {
+		return message
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(message)
+}
+
+func statusMessageKey(code int) string {
+	return "http." + strconv.Itoa(code)
+}
+
+func newDefaultCatalog() *Catalog {
+	c := NewCatalog(LanguageEnglish)
+
+	english := map[string]string{}
+	for code, m := range statusMessage {
+		if m != "" {
+			english[statusMessageKey(code)] = m
+		}
+	}
+	c.AddMessages(LanguageEnglish, english)
+
+	c.AddMessages(LanguageIndonesian, map[string]string{
+		"http.400": "Permintaan Tidak Valid",
+		"http.401": "Tidak Terautentikasi",
+		"http.403": "Akses Ditolak",
+		"http.404": "Data Tidak Ditemukan",
+		"http.405": "Metode Tidak Diizinkan",
+		"http.408": "Waktu Permintaan Habis",
+		"http.409": "Data Sudah Ada",
+		"http.422": "Data Tidak Dapat Diproses",
+		"http.429": "Terlalu Banyak Permintaan",
+		"http.500": "Terjadi Kesalahan Pada Server",
+		"http.502": "Gateway Bermasalah",
+		"http.503": "Layanan Tidak Tersedia",
+		"http.504": "Waktu Gateway Habis",
+	})
+	return c
+}
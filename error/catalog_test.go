@@ -0,0 +1,103 @@
+package error
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCatalog_Localize(t *testing.T) {
+	catalog := NewCatalog(LanguageEnglish)
+	err := catalog.LoadFS(fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{"balance.insufficient": "Insufficient balance, need {amount}"}`)},
+		"locales/id.yaml": {Data: []byte(`balance.insufficient: "Saldo tidak cukup, butuh {amount}"`)},
+	}, "locales/*")
+	if err != nil {
+		t.Fatalf("LoadFS() error = %v", err)
+	}
+	catalog.Add(LanguageIndonesian, "http.404", "Data Tidak Ditemukan")
+
+	localized := NewLocalizedError(422, FailedStatus, "balance.insufficient", map[string]interface{}{"amount": 5000})
+	tests := []struct {
+		name     string
+		err      error
+		language string
+		want     string
+	}{
+		{"indonesian", localized, "id", "Saldo tidak cukup, butuh 5000"},
+		{"regional indonesian", localized, "id-ID", "Saldo tidak cukup, butuh 5000"},
+		{"english", localized, "en", "Insufficient balance, need 5000"},
+		{"unsupported language", localized, "fr", "Insufficient balance, need 5000"},
+		{"wrapped", fmt.Errorf("usecase: %w", localized), "id", "Saldo tidak cukup, butuh 5000"},
+		{"status message", NewError(404, FailedStatus), "id", "Data Tidak Ditemukan"},
+		{"custom message", NewError(404, FailedStatus, "user not found"), "id", "user not found"},
+		{"not application error", fmt.Errorf("boom"), "id", "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalog.Localize(tt.err, tt.language); got != tt.want {
+				t.Errorf("Localize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalog_LoadFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "id.json")
+	if err := os.WriteFile(filename, []byte(`{"greeting": "Halo {name}"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(LanguageEnglish)
+	if err := catalog.LoadFile(filename); err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if got, _ := catalog.Message("greeting", "id", map[string]interface{}{"name": "Ewin"}); got != "Halo Ewin" {
+		t.Errorf("Message() = %v, want %v", got, "Halo Ewin")
+	}
+	if err := catalog.LoadFile(filepath.Join(dir, "id.txt")); err == nil {
+		t.Errorf("LoadFile() error = nil, want error")
+	}
+}
+
+func TestDefaultCatalog_StatusMessage(t *testing.T) {
+	if got := Localize(NewError(500, FailedStatus), LanguageIndonesian); got != "Terjadi Kesalahan Pada Server" {
+		t.Errorf("Localize() = %v, want %v", got, "Terjadi Kesalahan Pada Server")
+	}
+	if got := Localize(NewError(500, FailedStatus), LanguageEnglish); got != "Internal Server Error" {
+		t.Errorf("Localize() = %v, want %v", got, "Internal Server Error")
+	}
+}
+
+func TestCatalog_MatchLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"exact", "id", "id"},
+		{"regional", "id-ID,id;q=0.9,en;q=0.8", "id"},
+		{"quality order", "en;q=0.5,id;q=0.9", "id"},
+		{"unsupported", "fr-FR,fr;q=0.9", "en"},
+		{"empty", "", "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultCatalog.MatchLanguage(tt.header); got != tt.want {
+				t.Errorf("MatchLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	got := ParseAcceptLanguage("en-US;q=0.8, id_ID, *;q=0.1, fr;q=0")
+	want := []string{"id-id", "en-us"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAcceptLanguage() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,155 @@
+package error
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var retryableCodes = map[int]bool{
+	408: true,
+	429: true,
+	502: true,
+	503: true,
+	504: true,
+}
+
+var retryableRPCCodes = map[codes.Code]bool{
+	codes.DeadlineExceeded:  true,
+	codes.ResourceExhausted: true,
+	codes.Aborted:           true,
+	codes.Unavailable:       true,
+}
+
+// IsRetryable reports whether the operation that returned err may be retried.
+// Cancellation by the caller and client errors are never retryable.
+func IsRetryable(err error) bool {
+	if err == nil || IsCanceled(err) {
+		return false
+	}
+	if appErr, ok := As(err); ok {
+		return appErr.Retryable || retryableCodes[appErr.ErrorCode]
+	}
+	if st, ok := statusFromError(err); ok {
+		return retryableRPCCodes[st.Code()]
+	}
+	return IsTemporary(err)
+}
+
+// IsRetryableCode reports whether an application or HTTP status code is worth retrying.
+func IsRetryableCode(code int) bool {
+	return retryableCodes[code]
+}
+
+// IsTemporary reports whether err is caused by a transient condition such as a
+// timeout, a reset connection or an overloaded upstream.
+func IsTemporary(err error) bool {
+	if err == nil || IsCanceled(err) {
+		return false
+	}
+	if IsTimeout(err) || isConnectionError(err) {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	if errors.As(err, &temporary) && temporary.Temporary() {
+		return true
+	}
+	if appErr, ok := As(err); ok {
+		return appErr.Retryable || retryableCodes[appErr.ErrorCode]
+	}
+	if st, ok := statusFromError(err); ok {
+		return retryableRPCCodes[st.Code()]
+	}
+	return false
+}
+
+// IsCanceled reports whether err comes from a canceled context or gRPC call.
+func IsCanceled(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return true
+	}
+	st, ok := statusFromError(err)
+	return ok && st.Code() == codes.Canceled
+}
+
+// IsClientError reports whether err carries a 4xx application code.
+func IsClientError(err error) bool {
+	code, ok := applicationCode(err)
+	return ok && code >= 400 && code < 500
+}
+
+// IsServerError reports whether err carries a 5xx application code.
+func IsServerError(err error) bool {
+	code, ok := applicationCode(err)
+	return ok && code >= 500 && code < 600
+}
+
+// IsNotFound also understands sql.ErrNoRows and os.ErrNotExist.
+func IsNotFound(err error) bool {
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, os.ErrNotExist) {
+		return true
+	}
+	return hasCode(err, 404)
+}
+
+func IsUnauthorized(err error) bool {
+	return hasCode(err, 401)
+}
+
+func IsForbidden(err error) bool {
+	return hasCode(err, 403)
+}
+
+func IsConflict(err error) bool {
+	return hasCode(err, 409)
+}
+
+func hasCode(err error, code int) bool {
+	c, ok := applicationCode(err)
+	return ok && c == code
+}
+
+// applicationCode returns the code of an ApplicationError or gRPC status in err's chain.
+func applicationCode(err error) (int, bool) {
+	if err == nil {
+		return 0, false
+	}
+	if appErr, ok := As(err); ok {
+		return appErr.ErrorCode, true
+	}
+	if st, ok := statusFromError(err); ok {
+		return ApplicationCode(st.Code()), true
+	}
+	return 0, false
+}
+
+func statusFromError(err error) (*status.Status, bool) {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return nil, false
+	}
+	return grpcErr.GRPCStatus(), true
+}
+
+func isConnectionError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ETIMEDOUT) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
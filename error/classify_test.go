@@ -0,0 +1,122 @@
+package error
+
+import (
+	"context"
+	"database/sql"
+	stdErrors "errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"deadline exceeded", ErrDeadlineExceeded, true},
+		{"context deadline", context.DeadlineExceeded, true},
+		{"context canceled", context.Canceled, false},
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}, true},
+		{"connection refused", fmt.Errorf("dial: %w", syscall.ECONNREFUSED), true},
+		{"grpc unavailable", status.Error(codes.Unavailable, "down"), true},
+		{"grpc canceled", status.Error(codes.Canceled, "canceled"), false},
+		{"grpc invalid argument", status.Error(codes.InvalidArgument, "bad"), false},
+		{"service unavailable", NewError(503, FailedStatus), true},
+		{"too many requests", NewError(429, FailedStatus), true},
+		{"bad request", NewError(400, FailedStatus), false},
+		{"retryable flag", &ApplicationError{ErrorCode: 500, Status: FailedStatus, Retryable: true}, true},
+		{"wrapped retryable", Wrap(syscall.ECONNRESET, 500, FailedStatus, "upstream"), false},
+		{"plain error", stdErrors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsTemporary(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"deadline exceeded", ErrDeadlineExceeded, true},
+		{"broken pipe", fmt.Errorf("write: %w", syscall.EPIPE), true},
+		{"wrapped connection reset", Wrap(syscall.ECONNRESET, 500, FailedStatus, "upstream"), true},
+		{"context canceled", fmt.Errorf("query: %w", context.Canceled), false},
+		{"grpc resource exhausted", status.Error(codes.ResourceExhausted, "quota"), true},
+		{"not found", NewError(404, FailedStatus), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTemporary(tt.err); got != tt.want {
+				t.Errorf("IsTemporary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassification(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(error) bool
+		err  error
+		want bool
+	}{
+		{"client error", IsClientError, NewError(422, FailedStatus), true},
+		{"client error from grpc", IsClientError, status.Error(codes.NotFound, "missing"), true},
+		{"client error on server error", IsClientError, NewError(500, FailedStatus), false},
+		{"client error on plain error", IsClientError, stdErrors.New("boom"), false},
+		{"server error", IsServerError, status.Error(codes.Internal, "boom"), true},
+		{"server error on client error", IsServerError, NewError(400, FailedStatus), false},
+		{"not found", IsNotFound, fmt.Errorf("repo: %w", NewError(404, FailedStatus)), true},
+		{"not found sql", IsNotFound, fmt.Errorf("repo: %w", sql.ErrNoRows), true},
+		{"not found grpc", IsNotFound, status.Error(codes.NotFound, "missing"), true},
+		{"not found on conflict", IsNotFound, NewError(409, FailedStatus), false},
+		{"unauthorized", IsUnauthorized, status.Error(codes.Unauthenticated, "token"), true},
+		{"forbidden", IsForbidden, NewError(403, FailedStatus), true},
+		{"conflict", IsConflict, status.Error(codes.AlreadyExists, "exists"), true},
+		{"canceled", IsCanceled, status.Error(codes.Canceled, "canceled"), true},
+		{"canceled on nil", IsCanceled, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.err); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRetryableCode(t *testing.T) {
+	tests := []struct {
+		code int
+		want bool
+	}{
+		{429, true},
+		{503, true},
+		{504, true},
+		{500, false},
+		{400, false},
+		{200, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.code), func(t *testing.T) {
+			if got := IsRetryableCode(tt.code); got != tt.want {
+				t.Errorf("IsRetryableCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
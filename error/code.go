@@ -0,0 +1,144 @@
+package error
+
+import (
+	"sync"
+
+	"google.golang.org/grpc/codes"
+)
+
+// CodeRegistry maps gRPC codes to application (HTTP) codes and back.
+// The defaults follow the mapping documented in google/rpc/code.proto.
+type CodeRegistry struct {
+	mu     sync.RWMutex
+	toApp  map[codes.Code]int
+	toGRPC map[int]codes.Code
+}
+
+// DefaultCodeRegistry is used by ParseError and ApplicationError.GRPCStatus.
+// Override or extend it once at startup.
+var DefaultCodeRegistry = NewCodeRegistry()
+
+func NewCodeRegistry() *CodeRegistry {
+	r := &CodeRegistry{
+		toApp:  map[codes.Code]int{},
+		toGRPC: map[int]codes.Code{},
+	}
+	for code, appCode := range defaultRPCCodeToApplicationCode {
+		r.toApp[code] = appCode
+	}
+	for appCode, code := range defaultApplicationCodeToRPCCode {
+		r.toGRPC[appCode] = code
+	}
+	return r
+}
+
+// SetApplicationCode overrides the application code returned for a gRPC code.
+func (r *CodeRegistry) SetApplicationCode(code codes.Code, applicationCode int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.toApp[code] = applicationCode
+}
+
+// SetGRPCCode overrides the gRPC code sent for an application code.
+func (r *CodeRegistry) SetGRPCCode(applicationCode int, code codes.Code) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.toGRPC[applicationCode] = code
+}
+
+// Register maps code and applicationCode to each other in both directions.
+// Use it for custom application codes shared between services.
+func (r *CodeRegistry) Register(code codes.Code, applicationCode int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.toApp[code] = applicationCode
+	r.toGRPC[applicationCode] = code
+}
+
+// ApplicationCode returns the application code for a gRPC code.
+// Codes that are not registered are returned as is.
+func (r *CodeRegistry) ApplicationCode(code codes.Code) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if appCode, ok := r.toApp[code]; ok {
+		return appCode
+	}
+	return int(code)
+}
+
+// GRPCCode returns the gRPC code for an application code.
+// Unregistered 5xx codes become Internal, anything else Unknown.
+func (r *CodeRegistry) GRPCCode(applicationCode int) codes.Code {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if code, ok := r.toGRPC[applicationCode]; ok {
+		return code
+	}
+	if applicationCode >= 500 && applicationCode < 600 {
+		return codes.Internal
+	}
+	return codes.Unknown
+}
+
+// SetApplicationCode overrides the application code of a gRPC code in DefaultCodeRegistry.
+func SetApplicationCode(code codes.Code, applicationCode int) {
+	DefaultCodeRegistry.SetApplicationCode(code, applicationCode)
+}
+
+// SetGRPCCode overrides the gRPC code of an application code in DefaultCodeRegistry.
+func SetGRPCCode(applicationCode int, code codes.Code) {
+	DefaultCodeRegistry.SetGRPCCode(applicationCode, code)
+}
+
+// RegisterCode maps code and applicationCode to each other in DefaultCodeRegistry.
+func RegisterCode(code codes.Code, applicationCode int) {
+	DefaultCodeRegistry.Register(code, applicationCode)
+}
+
+// ApplicationCode returns the application code of a gRPC code from DefaultCodeRegistry.
+func ApplicationCode(code codes.Code) int {
+	return DefaultCodeRegistry.ApplicationCode(code)
+}
+
+// GRPCCode returns the gRPC code of an application code from DefaultCodeRegistry.
+func GRPCCode(applicationCode int) codes.Code {
+	return DefaultCodeRegistry.GRPCCode(applicationCode)
+}
+
+var defaultRPCCodeToApplicationCode = map[codes.Code]int{
+	codes.OK:                 200,
+	codes.Canceled:           499,
+	codes.Unknown:            500,
+	codes.InvalidArgument:    400,
+	codes.DeadlineExceeded:   504,
+	codes.NotFound:           404,
+	codes.AlreadyExists:      409,
+	codes.PermissionDenied:   403,
+	codes.ResourceExhausted:  429,
+	codes.FailedPrecondition: 400,
+	codes.Aborted:            409,
+	codes.OutOfRange:         400,
+	codes.Unimplemented:      501,
+	codes.Internal:           500,
+	codes.Unavailable:        503,
+	codes.DataLoss:           500,
+	codes.Unauthenticated:    401,
+}
+
+var defaultApplicationCodeToRPCCode = map[int]codes.Code{
+	400: codes.InvalidArgument,
+	401: codes.Unauthenticated,
+	403: codes.PermissionDenied,
+	404: codes.NotFound,
+	408: codes.DeadlineExceeded,
+	409: codes.AlreadyExists,
+	412: codes.FailedPrecondition,
+	413: codes.OutOfRange,
+	429: codes.ResourceExhausted,
+	499: codes.Canceled,
+	500: codes.Internal,
+	501: codes.Unimplemented,
+	502: codes.Unavailable,
+	503: codes.Unavailable,
+	504: codes.DeadlineExceeded,
+}
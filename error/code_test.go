@@ -0,0 +1,90 @@
+package error
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestCodeRegistry_ApplicationCode(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.OK, 200},
+		{codes.Canceled, 499},
+		{codes.Unknown, 500},
+		{codes.InvalidArgument, 400},
+		{codes.DeadlineExceeded, 504},
+		{codes.NotFound, 404},
+		{codes.AlreadyExists, 409},
+		{codes.PermissionDenied, 403},
+		{codes.ResourceExhausted, 429},
+		{codes.FailedPrecondition, 400},
+		{codes.Aborted, 409},
+		{codes.OutOfRange, 400},
+		{codes.Unimplemented, 501},
+		{codes.Internal, 500},
+		{codes.Unavailable, 503},
+		{codes.DataLoss, 500},
+		{codes.Unauthenticated, 401},
+		{codes.Code(451), 451},
+	}
+	r := NewCodeRegistry()
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			if got := r.ApplicationCode(tt.code); got != tt.want {
+				t.Errorf("ApplicationCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeRegistry_GRPCCode(t *testing.T) {
+	tests := []struct {
+		name string
+		code int
+		want codes.Code
+	}{
+		{"bad request", 400, codes.InvalidArgument},
+		{"unauthorized", 401, codes.Unauthenticated},
+		{"forbidden", 403, codes.PermissionDenied},
+		{"not found", 404, codes.NotFound},
+		{"conflict", 409, codes.AlreadyExists},
+		{"too many requests", 429, codes.ResourceExhausted},
+		{"client closed request", 499, codes.Canceled},
+		{"internal server error", 500, codes.Internal},
+		{"not implemented", 501, codes.Unimplemented},
+		{"service unavailable", 503, codes.Unavailable},
+		{"gateway timeout", 504, codes.DeadlineExceeded},
+		{"unmapped server error", 507, codes.Internal},
+		{"unmapped client error", 418, codes.Unknown},
+	}
+	r := NewCodeRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := r.GRPCCode(tt.code); got != tt.want {
+				t.Errorf("GRPCCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeRegistry_Register(t *testing.T) {
+	r := NewCodeRegistry()
+	r.Register(codes.Code(100), 4001)
+	r.SetApplicationCode(codes.DeadlineExceeded, 408)
+
+	if got := r.ApplicationCode(codes.Code(100)); got != 4001 {
+		t.Errorf("ApplicationCode() = %v, want %v", got, 4001)
+	}
+	if got := r.GRPCCode(4001); got != codes.Code(100) {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.Code(100))
+	}
+	if got := r.ApplicationCode(codes.DeadlineExceeded); got != 408 {
+		t.Errorf("ApplicationCode() = %v, want %v", got, 408)
+	}
+	if got := NewCodeRegistry().ApplicationCode(codes.DeadlineExceeded); got != 504 {
+		t.Errorf("new registry ApplicationCode() = %v, want %v", got, 504)
+	}
+}
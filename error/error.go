@@ -1,6 +1,8 @@
 package error
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"strings"
@@ -30,19 +32,108 @@ func New(errorCode int, status, message string) error {
 		ErrorCode: errorCode,
 		Status:    status,
 		Message:   message,
+		stack:     callers(),
 	}
 }
 
+// Wrap returns an ApplicationError that keeps err as its cause, so the original
+// error stays reachable through errors.Is and errors.As.
+func Wrap(err error, errorCode int, status, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &ApplicationError{
+		ErrorCode: errorCode,
+		Status:    status,
+		Message:   message,
+		cause:     err,
+		stack:     callers(),
+	}
+}
+
+// Wrapf is like Wrap but formats the message.
+func Wrapf(err error, errorCode int, status, format string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &ApplicationError{
+		ErrorCode: errorCode,
+		Status:    status,
+		Message:   fmt.Sprintf(format, args...),
+		cause:     err,
+		stack:     callers(),
+	}
+}
+
+// WrapError is like NewError but keeps err as the cause.
+func WrapError(err error, code int, status string, message ...string) error {
+	if err == nil {
+		return nil
+	}
+	appErr := newError(code, status, message...)
+	appErr.cause = err
+	appErr.stack = callers()
+	return appErr
+}
+
 type ApplicationError struct {
 	ErrorCode int
 	Status    string
 	Message   string
+	Details   map[string]string
+	// BusinessCode is a stable code such as "WAL-1002", see Register.
+	BusinessCode string
+	Retryable    bool
+	// MessageKey and MessageParams let a Catalog render Message in another language.
+	MessageKey    string
+	MessageParams map[string]interface{}
+	cause         error
+	stack         []uintptr
 }
 
 func (e *ApplicationError) Error() string {
 	return e.Message
 }
 
+// Unwrap returns the underlying cause, if any.
+func (e *ApplicationError) Unwrap() error {
+	return e.cause
+}
+
+// Cause keeps compatibility with github.com/pkg/errors.Cause.
+func (e *ApplicationError) Cause() error {
+	return e.cause
+}
+
+// Is reports whether target is an ApplicationError with the same business code,
+// or with the same code and status when target has no business code.
+func (e *ApplicationError) Is(target error) bool {
+	t, ok := target.(*ApplicationError)
+	if !ok {
+		return false
+	}
+	if t.BusinessCode != "" {
+		return e.BusinessCode == t.BusinessCode
+	}
+	return e.ErrorCode == t.ErrorCode && e.Status == t.Status
+}
+
+// As finds the first ApplicationError in err's chain. For a ValidationError it returns
+// a copy of the embedded ApplicationError caused by the ValidationError, so the gRPC
+// status and the problem still carry the invalid fields.
+func As(err error) (*ApplicationError, bool) {
+	var appErr *ApplicationError
+	if !errors.As(err, &appErr) {
+		return nil, false
+	}
+	if validationErr, ok := AsValidationError(err); ok && appErr == &validationErr.ApplicationError {
+		clone := validationErr.ApplicationError
+		clone.cause = validationErr
+		return &clone, true
+	}
+	return appErr, true
+}
+
 func IsTimeout(err error) bool {
 	if os.IsTimeout(err) {
 		return true
@@ -61,7 +152,7 @@ func GetCode(err error) int {
 		return 200
 	}
 
-	if he, ok := err.(*ApplicationError); ok {
+	if he, ok := As(err); ok {
 		return he.ErrorCode
 	}
 
@@ -73,9 +164,17 @@ func ParseError(err error) *ApplicationError {
 		return nil
 	}
 
+	// Check application error
+	if he, ok := As(err); ok {
+		return he
+	}
+
 	// Check grpc error
 	if he, ok := status.FromError(err); ok {
-		code := codeApplication(he.Code())
+		if appErr, ok := fromStatus(he); ok {
+			return appErr
+		}
+		code := ApplicationCode(he.Code())
 		if code == SuccessCode {
 			return nil
 		}
@@ -86,11 +185,6 @@ func ParseError(err error) *ApplicationError {
 		}
 	}
 
-	// Check application error
-	if he, ok := err.(*ApplicationError); ok {
-		return he
-	}
-
 	// Default error
 	m := err.Error()
 	sErr := strings.Split(err.Error(), "=")
@@ -106,6 +200,12 @@ func ParseError(err error) *ApplicationError {
 
 // NewError creates a new Error instance with an optional message
 func NewError(code int, status string, message ...string) error {
+	err := newError(code, status, message...)
+	err.stack = callers()
+	return err
+}
+
+func newError(code int, status string, message ...string) *ApplicationError {
 	err := &ApplicationError{
 		ErrorCode: code,
 		Status:    status,
@@ -218,34 +318,3 @@ func DeadlineExceededError(message ...string) error {
 		timeout: true,
 	}
 }
-
-var rpcCodeToApplicationCode = map[codes.Code]int{
-	codes.OK:                 200,
-	codes.Canceled:           406,
-	codes.Unknown:            500,
-	codes.InvalidArgument:    400,
-	codes.DeadlineExceeded:   451,
-	codes.NotFound:           404,
-	codes.AlreadyExists:      409,
-	codes.PermissionDenied:   403,
-	codes.ResourceExhausted:  500,
-	codes.FailedPrecondition: 500,
-	codes.Aborted:            500,
-	codes.OutOfRange:         413,
-	codes.Unimplemented:      501,
-	codes.Internal:           500,
-	codes.Unavailable:        502,
-	codes.DataLoss:           500,
-	codes.Unauthenticated:    401,
-}
-
-func codeApplication(status codes.Code) int {
-	if int(status) >= len(rpcCodeToApplicationCode) {
-		return int(status)
-	}
-	m := rpcCodeToApplicationCode[status]
-	if m == 0 {
-		m = int(status)
-	}
-	return m
-}
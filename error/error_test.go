@@ -1,6 +1,8 @@
 package error
 
 import (
+	stdErrors "errors"
+	"fmt"
 	"net/http"
 	"reflect"
 	"testing"
@@ -20,6 +22,7 @@ func TestGetCode(t *testing.T) {
 	}{
 		{"Custom Error", args{err: NewError(451, "FAILED", "set error pending")}, 451},
 		{"Error", args{err: errors.New("set error")}, 500},
+		{"Wrapped Custom Error", args{err: fmt.Errorf("repo: %w", NewError(404, FailedStatus))}, 404},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,10 +74,15 @@ func TestParseError(t *testing.T) {
 			Message:   "error rpc gan",
 		}},
 		{"rpc Error", args{err: status.Error(11, "error rpc gan")}, &ApplicationError{
-			ErrorCode: 413,
+			ErrorCode: 400,
 			Status:    FailedStatus,
 			Message:   "error rpc gan",
 		}},
+		{"Wrapped Custom Error", args{err: fmt.Errorf("service: %w", New(409, FailedStatus, "duplicate"))}, &ApplicationError{
+			ErrorCode: 409,
+			Status:    FailedStatus,
+			Message:   "duplicate",
+		}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -203,3 +211,65 @@ func TestIsTimeout(t *testing.T) {
 		})
 	}
 }
+
+func TestWrap(t *testing.T) {
+	cause := errors.New("sql: no rows in result set")
+	type args struct {
+		err       error
+		errorCode int
+		status    string
+		message   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantNil bool
+	}{
+		{"wrap error", args{err: cause, errorCode: 404, status: FailedStatus, message: "data not found"}, false},
+		{"wrap nil", args{err: nil, errorCode: 404, status: FailedStatus, message: "data not found"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Wrap(tt.args.err, tt.args.errorCode, tt.args.status, tt.args.message)
+			if (err == nil) != tt.wantNil {
+				t.Fatalf("Wrap() = %v, wantNil %v", err, tt.wantNil)
+			}
+			if tt.wantNil {
+				return
+			}
+			if !stdErrors.Is(err, cause) {
+				t.Errorf("errors.Is(Wrap(), cause) = false, want true")
+			}
+			if got := stdErrors.Unwrap(err); got != cause {
+				t.Errorf("Unwrap() = %v, want %v", got, cause)
+			}
+			if got := GetCode(fmt.Errorf("usecase: %w", err)); got != tt.args.errorCode {
+				t.Errorf("GetCode() = %v, want %v", got, tt.args.errorCode)
+			}
+			if got := errors.Cause(err); got != cause {
+				t.Errorf("errors.Cause() = %v, want %v", got, cause)
+			}
+		})
+	}
+}
+
+func TestApplicationError_Is(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"same code and status", Wrap(errors.New("db"), 404, FailedStatus, "not found"), NewError(404, FailedStatus), true},
+		{"different code", NewError(400, FailedStatus), NewError(404, FailedStatus), false},
+		{"different status", NewError(404, PendingStatus), NewError(404, FailedStatus), false},
+		{"not application error", errors.New("not found"), NewError(404, FailedStatus), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stdErrors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package error
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ErrorDomain identifies ErrorInfo details produced by this library.
+const ErrorDomain = "go-lib.ewinjuman.github.com"
+
+// Metadata keys of the ErrorInfo detail used by this library. They are namespaced so
+// details added with WithDetail, which share the metadata, cannot collide with them.
+const (
+	metadataPrefix          = "go-lib."
+	errorCodeMetadataKey    = metadataPrefix + "errorCode"
+	businessCodeMetadataKey = metadataPrefix + "businessCode"
+	fieldRulePrefix         = metadataPrefix + "fieldRule:"
+)
+
+// WithDetail adds extra detail that is carried along when the error is sent over gRPC.
+// Keys starting with go-lib. are reserved and not sent.
+func (e *ApplicationError) WithDetail(key, value string) *ApplicationError {
+	if e.Details == nil {
+		e.Details = map[string]string{}
+	}
+	e.Details[key] = value
+	return e
+}
+
+// GRPCStatus lets status.FromError and status.Code understand ApplicationError.
+// ErrorCode, Status and Details are sent as a google.rpc.ErrorInfo detail so ParseError
+// on the receiving side can restore the same ApplicationError.
+func (e *ApplicationError) GRPCStatus() *status.Status {
+	var validationErr *ValidationError
+	if errors.As(e.cause, &validationErr) {
+		return grpcStatus(e, validationErr.Fields)
+	}
+	return grpcStatus(e, nil)
+}
+
+// GRPCStatus adds the field errors as a google.rpc.BadRequest detail.
+func (e *ValidationError) GRPCStatus() *status.Status {
+	return grpcStatus(&e.ApplicationError, e.Fields)
+}
+
+func grpcStatus(e *ApplicationError, fields []FieldError) *status.Status {
+	st := status.New(GRPCCode(e.ErrorCode), e.Message)
+
+	metadata := map[string]string{}
+	for k, v := range e.Details {
+		// reserved keys cannot be sent as details
+		if !strings.HasPrefix(k, metadataPrefix) {
+			metadata[k] = v
+		}
+	}
+	metadata[errorCodeMetadataKey] = strconv.Itoa(e.ErrorCode)
+	if e.BusinessCode != "" {
+		metadata[businessCodeMetadataKey] = e.BusinessCode
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason:   e.Status,
+		Domain:   ErrorDomain,
+		Metadata: metadata,
+	}}
+	if len(fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, f := range fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       f.Field,
+				Description: f.Message,
+			})
+			metadata[fieldRulePrefix+f.Field] = f.Rule
+		}
+		details = append(details, badRequest)
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
+
+// fromStatus restores an ApplicationError from a gRPC status produced by GRPCStatus.
+func fromStatus(st *status.Status) (*ApplicationError, bool) {
+	var appErr *ApplicationError
+	var badRequest *errdetails.BadRequest
+	rules := map[string]string{}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if appErr != nil || d.GetDomain() != ErrorDomain {
+				continue
+			}
+			code, err := strconv.Atoi(d.GetMetadata()[errorCodeMetadataKey])
+			if err != nil {
+				continue
+			}
+			appErr = &ApplicationError{
+				ErrorCode: code,
+				Status:    d.GetReason(),
+				Message:   st.Message(),
+			}
+			for k, v := range d.GetMetadata() {
+				switch {
+				case k == errorCodeMetadataKey:
+				case k == businessCodeMetadataKey:
+					appErr.BusinessCode = v
+				case strings.HasPrefix(k, fieldRulePrefix):
+					rules[strings.TrimPrefix(k, fieldRulePrefix)] = v
+				default:
+					appErr.WithDetail(k, v)
+				}
+			}
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	if appErr == nil {
+		return nil, false
+	}
+	restoreRegistered(appErr)
+
+	if badRequest != nil {
+		validationErr := &ValidationError{ApplicationError: *appErr}
+		for _, v := range badRequest.GetFieldViolations() {
+			validationErr.Fields = append(validationErr.Fields, FieldError{
+				Field:   v.GetField(),
+				Rule:    rules[v.GetField()],
+				Message: v.GetDescription(),
+			})
+		}
+		appErr.cause = validationErr
+	}
+	return appErr, true
+}
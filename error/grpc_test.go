@@ -0,0 +1,78 @@
+package error
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestApplicationError_GRPCStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{"not found", NewError(404, FailedStatus), codes.NotFound},
+		{"bad request", New(400, FailedStatus, "invalid"), codes.InvalidArgument},
+		{"wrapped", fmt.Errorf("usecase: %w", New(401, FailedStatus, "token expired")), codes.Unauthenticated},
+		{"unmapped", New(418, FailedStatus, "teapot"), codes.Unknown},
+		{"unmapped server error", New(507, FailedStatus, "storage"), codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.err); got != tt.wantCode {
+				t.Errorf("status.Code() = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestParseError_GRPCRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *ApplicationError
+	}{
+		{"pending status", &ApplicationError{ErrorCode: 202, Status: PendingStatus, Message: "waiting for callback"}},
+		{"with details", (&ApplicationError{ErrorCode: 422, Status: FailedStatus, Message: "limit reached"}).WithDetail("limit", "1000000")},
+		{"unmapped code", &ApplicationError{ErrorCode: 451, Status: FailedStatus, Message: "blocked"}},
+		{"detail named like the code", (&ApplicationError{ErrorCode: 409, Status: FailedStatus, Message: "duplicate"}).
+			WithDetail("errorCode", "DUPLICATE_TRX").WithDetail("businessCode", "B01")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Simulate the wire: only the proto form of the status crosses services.
+			received := status.ErrorProto(status.Convert(tt.err).Proto())
+			if got := ParseError(received); !reflect.DeepEqual(got, tt.err) {
+				t.Errorf("ParseError() = %+v, want %+v", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestApplicationError_GRPCStatusReservedDetail(t *testing.T) {
+	err := (&ApplicationError{ErrorCode: 404, Status: FailedStatus, Message: "not found"}).WithDetail(errorCodeMetadataKey, "500")
+	received := status.ErrorProto(status.Convert(err).Proto())
+	got := ParseError(received)
+	if got.ErrorCode != 404 {
+		t.Errorf("ParseError().ErrorCode = %v, want 404", got.ErrorCode)
+	}
+	if _, ok := got.Details[errorCodeMetadataKey]; ok {
+		t.Errorf("ParseError().Details = %v, want no reserved key", got.Details)
+	}
+}
+
+func TestSetGRPCCode(t *testing.T) {
+	previous := GRPCCode(409)
+	defer SetGRPCCode(409, previous)
+
+	SetGRPCCode(409, codes.Aborted)
+	if got := GRPCCode(409); got != codes.Aborted {
+		t.Errorf("GRPCCode() = %v, want %v", got, codes.Aborted)
+	}
+	if got := status.Code(New(409, FailedStatus, "conflict")); got != codes.Aborted {
+		t.Errorf("status.Code() = %v, want %v", got, codes.Aborted)
+	}
+}
@@ -0,0 +1,181 @@
+package error
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeBaseURI, when set, is joined with the business code to build the
+// problem "type", e.g. "https://errors.example.com/" + "WAL-1002".
+var ProblemTypeBaseURI = ""
+
+const (
+	problemTypeBlank            = "about:blank"
+	problemErrorCodeKey         = "errorCode"
+	problemApplicationStatusKey = "applicationStatus"
+	problemBusinessCodeKey      = "businessCode"
+	problemDetailsKey           = "details"
+	problemErrorsKey            = "errors"
+)
+
+// Problem is an RFC 7807 problem details document. Extensions are written as
+// top level members next to the standard ones.
+type Problem struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]interface{}
+}
+
+// Problem renders e as an RFC 7807 document. instance usually is the request path.
+func (e *ApplicationError) Problem(instance string) *Problem {
+	status := e.ErrorCode
+	if status < 100 || status > 599 {
+		status = http.StatusInternalServerError
+	}
+	p := &Problem{
+		Type:     problemTypeBlank,
+		Title:    StatusMessage(status),
+		Status:   status,
+		Detail:   e.Message,
+		Instance: instance,
+		Extensions: map[string]interface{}{
+			problemErrorCodeKey:         e.ErrorCode,
+			problemApplicationStatusKey: e.Status,
+		},
+	}
+	if e.BusinessCode != "" {
+		p.Extensions[problemBusinessCodeKey] = e.BusinessCode
+		if ProblemTypeBaseURI != "" {
+			p.Type = ProblemTypeBaseURI + e.BusinessCode
+		}
+	}
+	if len(e.Details) > 0 {
+		p.Extensions[problemDetailsKey] = e.Details
+	}
+	if validationErr, ok := AsValidationError(e.cause); ok {
+		p.Extensions[problemErrorsKey] = validationErr.Fields
+	}
+	return p
+}
+
+// Problem adds the invalid fields under the "errors" extension.
+func (e *ValidationError) Problem(instance string) *Problem {
+	p := e.ApplicationError.Problem(instance)
+	p.Extensions[problemErrorsKey] = e.Fields
+	return p
+}
+
+// ToProblem renders any error as an RFC 7807 document using ParseError.
+func ToProblem(err error, instance string) *Problem {
+	if validationErr, ok := AsValidationError(err); ok {
+		return validationErr.Problem(instance)
+	}
+	appErr := ParseError(err)
+	if appErr == nil {
+		return nil
+	}
+	return appErr.Problem(instance)
+}
+
+// ParseProblem reads an RFC 7807 document back into an ApplicationError.
+// Invalid fields in the "errors" extension are returned as a ValidationError cause.
+func ParseProblem(data []byte) (*ApplicationError, error) {
+	var p Problem
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, err
+	}
+	return p.ApplicationError(), nil
+}
+
+// ApplicationError converts the problem back into an ApplicationError.
+func (p *Problem) ApplicationError() *ApplicationError {
+	appErr := &ApplicationError{
+		ErrorCode: p.Status,
+		Status:    FailedStatus,
+		Message:   p.Detail,
+	}
+	if appErr.Message == "" {
+		appErr.Message = p.Title
+	}
+
+	raw, _ := json.Marshal(p.Extensions)
+	var ext struct {
+		ErrorCode         *int              `json:"errorCode"`
+		ApplicationStatus string            `json:"applicationStatus"`
+		BusinessCode      string            `json:"businessCode"`
+		Details           map[string]string `json:"details"`
+		Errors            []FieldError      `json:"errors"`
+	}
+	_ = json.Unmarshal(raw, &ext)
+
+	if ext.ErrorCode != nil {
+		appErr.ErrorCode = *ext.ErrorCode
+	}
+	if ext.ApplicationStatus != "" {
+		appErr.Status = ext.ApplicationStatus
+	}
+	appErr.BusinessCode = ext.BusinessCode
+	appErr.Details = ext.Details
+	restoreRegistered(appErr)
+
+	if len(ext.Errors) > 0 {
+		appErr.cause = &ValidationError{ApplicationError: *appErr, Fields: ext.Errors}
+	}
+	return appErr
+}
+
+func (p Problem) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{}, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		m[k] = v
+	}
+	m["type"] = p.Type
+	if p.Type == "" {
+		m["type"] = problemTypeBlank
+	}
+	m["title"] = p.Title
+	m["status"] = p.Status
+	if p.Detail != "" {
+		m["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		m["instance"] = p.Instance
+	}
+	return json.Marshal(m)
+}
+
+func (p *Problem) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*p = Problem{Extensions: map[string]interface{}{}}
+	for k, raw := range m {
+		var err error
+		switch k {
+		case "type":
+			err = json.Unmarshal(raw, &p.Type)
+		case "title":
+			err = json.Unmarshal(raw, &p.Title)
+		case "status":
+			err = json.Unmarshal(raw, &p.Status)
+		case "detail":
+			err = json.Unmarshal(raw, &p.Detail)
+		case "instance":
+			err = json.Unmarshal(raw, &p.Instance)
+		default:
+			var v interface{}
+			err = json.Unmarshal(raw, &v)
+			p.Extensions[k] = v
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
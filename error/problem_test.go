@@ -0,0 +1,90 @@
+package error
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestApplicationError_Problem(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		instance string
+		want     string
+	}{
+		{
+			"application error",
+			New(409, FailedStatus, "account already exists"),
+			"/v1/accounts",
+			`{"applicationStatus":"FAILED","detail":"account already exists","errorCode":409,"instance":"/v1/accounts","status":409,"title":"Conflict","type":"about:blank"}`,
+		},
+		{
+			"custom application code",
+			New(4001, PendingStatus, "waiting"),
+			"",
+			`{"applicationStatus":"PENDING","detail":"waiting","errorCode":4001,"status":500,"title":"Internal Server Error","type":"about:blank"}`,
+		},
+		{
+			"validation error",
+			NewValidationError(FieldError{Field: "pin", Rule: "required", Message: "pin is required"}),
+			"",
+			`{"applicationStatus":"FAILED","detail":"pin is required","errorCode":400,"errors":[{"field":"pin","rule":"required","message":"pin is required"}],"status":400,"title":"Bad Request","type":"about:blank"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(ToProblem(tt.err, tt.instance))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("ToProblem() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *ApplicationError
+	}{
+		{"application error", &ApplicationError{ErrorCode: 409, Status: FailedStatus, Message: "account already exists"}},
+		{"custom application code", &ApplicationError{ErrorCode: 4001, Status: PendingStatus, Message: "waiting", Details: map[string]string{"ref": "abc"}}},
+		{"registered", &ApplicationError{ErrorCode: 503, Status: FailedStatus, Message: "Upstream busy", BusinessCode: "TST-5001", Retryable: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, _ := json.Marshal(tt.err.Problem("/v1/accounts"))
+			got, err := ParseProblem(data)
+			if err != nil {
+				t.Fatalf("ParseProblem() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.err) {
+				t.Errorf("ParseProblem() = %+v, want %+v", got, tt.err)
+			}
+		})
+	}
+}
+
+func TestParseProblem_Foreign(t *testing.T) {
+	data := []byte(`{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit.","status":403,"detail":"Your current balance is 30, but that costs 50.","balance":30}`)
+	got, err := ParseProblem(data)
+	if err != nil {
+		t.Fatalf("ParseProblem() error = %v", err)
+	}
+	want := &ApplicationError{ErrorCode: 403, Status: FailedStatus, Message: "Your current balance is 30, but that costs 50."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseProblem() = %+v, want %+v", got, want)
+	}
+
+	validation, _ := ParseProblem([]byte(`{"status":400,"title":"Bad Request","errors":[{"field":"pin","rule":"len","message":"pin length must be 6"}]}`))
+	if validationErr, ok := AsValidationError(validation); !ok || len(validationErr.Fields) != 1 {
+		t.Errorf("ParseProblem() validation = %+v, want one field error", validation)
+	}
+
+	if _, err = ParseProblem([]byte(`not json`)); err == nil {
+		t.Errorf("ParseProblem() error = nil, want error")
+	}
+}
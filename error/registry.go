@@ -0,0 +1,127 @@
+package error
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Definition declares a named business error.
+type Definition struct {
+	BusinessCode string
+	ErrorCode    int
+	Status       string
+	Message      string
+	MessageKey   string
+	Retryable    bool
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]*ApplicationError{}
+)
+
+// Register declares a business error once, usually as a package level variable:
+//
+//	var ErrInsufficientBalance = error.Register(error.Definition{
+//		BusinessCode: "WAL-1002",
+//		ErrorCode:    422,
+//		Status:       error.FailedStatus,
+//		Message:      "Insufficient balance",
+//	})
+//
+// The returned error is shared, use Clone or Wrap before changing it.
+// Register panics when the business code is empty or already registered.
+func Register(d Definition) *ApplicationError {
+	if d.BusinessCode == "" {
+		panic("error: Register with empty business code")
+	}
+	if d.Status == "" {
+		d.Status = FailedStatus
+	}
+	if d.Message == "" {
+		d.Message = StatusMessage(d.ErrorCode)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, exists := registry[d.BusinessCode]; exists {
+		panic(fmt.Sprintf("error: business code %s registered twice", d.BusinessCode))
+	}
+	appErr := &ApplicationError{
+		ErrorCode:    d.ErrorCode,
+		Status:       d.Status,
+		Message:      d.Message,
+		BusinessCode: d.BusinessCode,
+		Retryable:    d.Retryable,
+		MessageKey:   d.MessageKey,
+	}
+	registry[d.BusinessCode] = appErr
+	return appErr
+}
+
+// Lookup returns a copy of the error registered with businessCode.
+func Lookup(businessCode string) (*ApplicationError, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	appErr, ok := registry[businessCode]
+	if !ok {
+		return nil, false
+	}
+	return appErr.Clone(), true
+}
+
+// Registered returns the business codes of all registered errors, sorted.
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	businessCodes := make([]string, 0, len(registry))
+	for code := range registry {
+		businessCodes = append(businessCodes, code)
+	}
+	sort.Strings(businessCodes)
+	return businessCodes
+}
+
+// Clone returns a copy of e without its cause and stack trace.
+func (e *ApplicationError) Clone() *ApplicationError {
+	clone := *e
+	clone.cause = nil
+	clone.stack = nil
+	if e.Details != nil {
+		clone.Details = make(map[string]string, len(e.Details))
+		for k, v := range e.Details {
+			clone.Details[k] = v
+		}
+	}
+	if e.MessageParams != nil {
+		clone.MessageParams = make(map[string]interface{}, len(e.MessageParams))
+		for k, v := range e.MessageParams {
+			clone.MessageParams[k] = v
+		}
+	}
+	return &clone
+}
+
+// Wrap returns a copy of e with err as its cause.
+func (e *ApplicationError) Wrap(err error) error {
+	clone := e.Clone()
+	clone.cause = err
+	clone.stack = callers()
+	return clone
+}
+
+// restoreRegistered fills the fields that are not sent over the wire from the registry.
+func restoreRegistered(appErr *ApplicationError) {
+	if appErr.BusinessCode == "" {
+		return
+	}
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if registered, ok := registry[appErr.BusinessCode]; ok {
+		appErr.Retryable = registered.Retryable
+		if appErr.MessageKey == "" {
+			appErr.MessageKey = registered.MessageKey
+		}
+	}
+}
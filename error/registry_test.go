@@ -0,0 +1,94 @@
+package error
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/status"
+)
+
+var (
+	errTestInsufficientBalance = Register(Definition{
+		BusinessCode: "TST-1002",
+		ErrorCode:    422,
+		Message:      "Insufficient balance",
+	})
+	errTestUpstreamBusy = Register(Definition{
+		BusinessCode: "TST-5001",
+		ErrorCode:    503,
+		Message:      "Upstream busy",
+		Retryable:    true,
+	})
+)
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Definition
+	}{
+		{"empty business code", Definition{ErrorCode: 400}},
+		{"duplicate business code", Definition{BusinessCode: "TST-1002", ErrorCode: 400}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register() did not panic")
+				}
+			}()
+			Register(tt.d)
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	got, ok := Lookup("TST-1002")
+	if !ok {
+		t.Fatalf("Lookup() ok = false, want true")
+	}
+	want := &ApplicationError{ErrorCode: 422, Status: FailedStatus, Message: "Insufficient balance", BusinessCode: "TST-1002"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Lookup() = %+v, want %+v", got, want)
+	}
+	if got == errTestInsufficientBalance {
+		t.Errorf("Lookup() returned the shared registered error, want a copy")
+	}
+	if _, ok = Lookup("TST-0000"); ok {
+		t.Errorf("Lookup() ok = true for unknown code, want false")
+	}
+}
+
+func TestApplicationError_IsRegistered(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"registered", errTestInsufficientBalance, errTestInsufficientBalance, true},
+		{"wrapped copy", fmt.Errorf("usecase: %w", errTestInsufficientBalance.Wrap(stdErrors.New("db"))), errTestInsufficientBalance, true},
+		{"same http code other business code", New(422, FailedStatus, "Insufficient balance"), errTestInsufficientBalance, false},
+		{"other registered", errTestUpstreamBusy, errTestInsufficientBalance, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := stdErrors.Is(tt.err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseError_RegisteredGRPCRoundTrip(t *testing.T) {
+	received := status.ErrorProto(status.Convert(errTestUpstreamBusy).Proto())
+
+	got := ParseError(received)
+	if !stdErrors.Is(got, errTestUpstreamBusy) {
+		t.Errorf("ParseError() = %+v, want %+v", got, errTestUpstreamBusy)
+	}
+	if !got.Retryable {
+		t.Errorf("ParseError().Retryable = false, want true")
+	}
+}
@@ -0,0 +1,70 @@
+package error
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+const maxStackDepth = 32
+
+var stackTraceEnabled atomic.Bool
+
+// EnableStackTrace switches stack capture on or off for newly created errors.
+// It is off by default because runtime.Callers is not free on hot paths.
+func EnableStackTrace(enabled bool) {
+	stackTraceEnabled.Store(enabled)
+}
+
+func StackTraceEnabled() bool {
+	return stackTraceEnabled.Load()
+}
+
+// callers records the stack of the function calling the constructor.
+func callers() []uintptr {
+	if !stackTraceEnabled.Load() {
+		return nil
+	}
+	var pcs [maxStackDepth]uintptr
+	// skip runtime.Callers, callers and the constructor itself
+	n := runtime.Callers(3, pcs[:])
+	return pcs[:n]
+}
+
+// StackTrace returns where the error was created, or nil when stack capture is disabled.
+// The type matches github.com/pkg/errors so existing stack tracer integrations work.
+func (e *ApplicationError) StackTrace() errors.StackTrace {
+	if len(e.stack) == 0 {
+		return nil
+	}
+	frames := make(errors.StackTrace, len(e.stack))
+	for i, pc := range e.stack {
+		frames[i] = errors.Frame(pc)
+	}
+	return frames
+}
+
+// Format prints the message for %s and %v. %+v adds the cause and the stack trace.
+func (e *ApplicationError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			_, _ = io.WriteString(s, e.Message)
+			if e.cause != nil {
+				_, _ = fmt.Fprintf(s, ": %+v", e.cause)
+			}
+			if st := e.StackTrace(); st != nil {
+				_, _ = fmt.Fprintf(s, "%+v", st)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		_, _ = io.WriteString(s, e.Message)
+	case 'q':
+		_, _ = fmt.Fprintf(s, "%q", e.Message)
+	}
+}
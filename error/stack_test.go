@@ -0,0 +1,59 @@
+package error
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStackTrace(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		create  func() error
+	}{
+		{"New", true, func() error { return New(400, FailedStatus, "invalid") }},
+		{"NewError", true, func() error { return NewError(400, FailedStatus) }},
+		{"Wrap", true, func() error { return Wrap(fmt.Errorf("db"), 500, FailedStatus, "failed") }},
+		{"disabled", false, func() error { return New(400, FailedStatus, "invalid") }},
+	}
+	defer EnableStackTrace(false)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			EnableStackTrace(tt.enabled)
+			err := tt.create().(*ApplicationError)
+
+			stack := err.StackTrace()
+			if !tt.enabled {
+				if stack != nil {
+					t.Errorf("StackTrace() = %v, want nil", stack)
+				}
+				return
+			}
+			if len(stack) == 0 {
+				t.Fatalf("StackTrace() is empty")
+			}
+			// the first frame is the caller of the constructor
+			if got := fmt.Sprintf("%n", stack[0]); !strings.Contains(got, "TestStackTrace") {
+				t.Errorf("first frame = %v, want the test function", got)
+			}
+		})
+	}
+}
+
+func TestApplicationError_Format(t *testing.T) {
+	defer EnableStackTrace(false)
+	EnableStackTrace(true)
+	err := Wrap(fmt.Errorf("connection refused"), 503, FailedStatus, "upstream unavailable")
+
+	if got := fmt.Sprintf("%v", err); got != "upstream unavailable" {
+		t.Errorf("%%v = %v, want %v", got, "upstream unavailable")
+	}
+	if got := fmt.Sprintf("%s", err); got != "upstream unavailable" {
+		t.Errorf("%%s = %v, want %v", got, "upstream unavailable")
+	}
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.HasPrefix(verbose, "upstream unavailable: connection refused") || !strings.Contains(verbose, "stack_test.go") {
+		t.Errorf("%%+v = %v, want message, cause and stack trace", verbose)
+	}
+}
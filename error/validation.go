@@ -0,0 +1,116 @@
+package error
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes a single field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationError is an ApplicationError with code 400 that lists every invalid field.
+// errors.As(err, &*ApplicationError) still works on it.
+type ValidationError struct {
+	ApplicationError
+	Fields []FieldError
+}
+
+func NewValidationError(fields ...FieldError) error {
+	messages := make([]string, 0, len(fields))
+	for _, f := range fields {
+		messages = append(messages, f.Message)
+	}
+	message := StatusMessage(http.StatusBadRequest)
+	if len(messages) > 0 {
+		message = strings.Join(messages, ", ")
+	}
+	return &ValidationError{
+		ApplicationError: ApplicationError{
+			ErrorCode: http.StatusBadRequest,
+			Status:    FailedStatus,
+			Message:   message,
+			stack:     callers(),
+		},
+		Fields: fields,
+	}
+}
+
+// FromValidator converts validator.ValidationErrors into a ValidationError.
+// Any other error is returned unchanged.
+func FromValidator(err error) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+	fields := make([]FieldError, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		fields = append(fields, FieldError{
+			Field:   fe.Field(),
+			Rule:    fe.Tag(),
+			Message: validationMessage(fe),
+		})
+	}
+	return NewValidationError(fields...)
+}
+
+// AsValidationError finds the first ValidationError in err's chain.
+func AsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
+
+// Unwrap exposes the embedded ApplicationError to errors.As.
+func (e *ValidationError) Unwrap() error {
+	return &e.ApplicationError
+}
+
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	fields := e.Fields
+	if fields == nil {
+		fields = []FieldError{}
+	}
+	return json.Marshal(struct {
+		Code    int          `json:"code"`
+		Status  string       `json:"status"`
+		Message string       `json:"message"`
+		Errors  []FieldError `json:"errors"`
+	}{
+		Code:    e.ErrorCode,
+		Status:  e.Status,
+		Message: e.Message,
+		Errors:  fields,
+	})
+}
+
+func validationMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return fmt.Sprintf("%s is required", fe.Field())
+	case "email":
+		return fmt.Sprintf("%s must be a valid email", fe.Field())
+	case "numeric", "number":
+		return fmt.Sprintf("%s must be numeric", fe.Field())
+	case "len":
+		return fmt.Sprintf("%s length must be %s", fe.Field(), fe.Param())
+	case "min", "gte":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "max", "lte":
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s]", fe.Field(), fe.Param())
+	default:
+		return fmt.Sprintf("%s is not valid", fe.Field())
+	}
+}
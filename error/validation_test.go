@@ -0,0 +1,117 @@
+package error
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/status"
+)
+
+type registerRequest struct {
+	Name  string `validate:"required"`
+	Email string `validate:"required,email"`
+	Pin   string `validate:"len=6"`
+}
+
+func TestFromValidator(t *testing.T) {
+	validate := validator.New()
+	tests := []struct {
+		name       string
+		request    registerRequest
+		wantFields []FieldError
+	}{
+		{"invalid request", registerRequest{Email: "ewin", Pin: "123"}, []FieldError{
+			{Field: "Name", Rule: "required", Message: "Name is required"},
+			{Field: "Email", Rule: "email", Message: "Email must be a valid email"},
+			{Field: "Pin", Rule: "len", Message: "Pin length must be 6"},
+		}},
+		{"valid request", registerRequest{Name: "ewin", Email: "ewin@mail.com", Pin: "123456"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromValidator(validate.Struct(tt.request))
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("FromValidator() = %v, want nil", err)
+				}
+				return
+			}
+			validationErr, ok := AsValidationError(err)
+			if !ok {
+				t.Fatalf("FromValidator() = %T, want *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Fields, tt.wantFields) {
+				t.Errorf("Fields = %v, want %v", validationErr.Fields, tt.wantFields)
+			}
+			if got := GetCode(err); got != 400 {
+				t.Errorf("GetCode() = %v, want %v", got, 400)
+			}
+		})
+	}
+}
+
+func TestValidationError_MarshalJSON(t *testing.T) {
+	err := NewValidationError(FieldError{Field: "pin", Rule: "len", Message: "pin length must be 6"})
+	got, _ := json.Marshal(err)
+	want := `{"code":400,"status":"FAILED","message":"pin length must be 6","errors":[{"field":"pin","rule":"len","message":"pin length must be 6"}]}`
+	if string(got) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
+
+func TestValidationError_GRPCRoundTrip(t *testing.T) {
+	fields := []FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "pin", Rule: "len", Message: "pin length must be 6"},
+	}
+	received := status.ErrorProto(status.Convert(fmt.Errorf("handler: %w", NewValidationError(fields...))).Proto())
+
+	parsed := ParseError(received)
+	if parsed.ErrorCode != 400 || parsed.Status != FailedStatus {
+		t.Fatalf("ParseError() = %+v, want code 400 and status FAILED", parsed)
+	}
+	validationErr, ok := AsValidationError(parsed)
+	if !ok {
+		t.Fatalf("AsValidationError() = false, want true")
+	}
+	if !reflect.DeepEqual(validationErr.Fields, fields) {
+		t.Errorf("Fields = %v, want %v", validationErr.Fields, fields)
+	}
+
+	// Forwarding the parsed error keeps the field violations.
+	forwarded := ParseError(status.ErrorProto(status.Convert(parsed).Proto()))
+	if validationErr, ok = AsValidationError(forwarded); !ok || !reflect.DeepEqual(validationErr.Fields, fields) {
+		t.Errorf("forwarded Fields = %v, want %v", validationErr, fields)
+	}
+}
+
+func TestParseError_ValidationError(t *testing.T) {
+	fields := []FieldError{
+		{Field: "name", Rule: "required", Message: "name is required"},
+		{Field: "pin", Rule: "len", Message: "pin length must be 6"},
+	}
+	validationErr := NewValidationError(fields...)
+	for _, err := range []error{validationErr, fmt.Errorf("handler: %w", validationErr)} {
+		parsed := ParseError(err)
+		if parsed.ErrorCode != 400 || parsed.Message != "name is required, pin length must be 6" {
+			t.Fatalf("ParseError() = %+v, want the validation error", parsed)
+		}
+		// ErrorInfo and BadRequest
+		if details := status.Convert(parsed).Details(); len(details) != 2 {
+			t.Errorf("status details = %v, want 2", details)
+		}
+		problem := parsed.Problem("")
+		if got, _ := problem.Extensions[problemErrorsKey].([]FieldError); !reflect.DeepEqual(got, fields) {
+			t.Errorf("problem errors = %v, want %v", problem.Extensions[problemErrorsKey], fields)
+		}
+		if got, ok := AsValidationError(parsed); !ok || got != validationErr {
+			t.Errorf("AsValidationError() = %v, want the original error", got)
+		}
+	}
+	if parsed := ParseError(validationErr); parsed == &validationErr.(*ValidationError).ApplicationError {
+		t.Error("ParseError() returned the embedded ApplicationError")
+	}
+}
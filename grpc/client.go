@@ -2,8 +2,10 @@ package grpc
 
 import (
 	"context"
+	"math/rand"
 	"time"
 
+	Error "github.com/ewinjuman/go-lib/error"
 	Session "github.com/ewinjuman/go-lib/session"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
@@ -12,8 +14,24 @@ import (
 type Options struct {
 	Address string        `json:"address"`
 	Timeout time.Duration `json:"timeout"`
+	// RetryCount enables retries of errors classified by Error.IsRetryable, 0 disables it.
+	// Only RetryMethods, or calls with an idempotency-key metadata entry, are retried.
+	RetryCount int `json:"retryCount"`
+	// RetryWaitTime is the first wait, doubled after every attempt up to two seconds.
+	RetryWaitTime time.Duration `json:"retryWaitTime"` // in milliseconds, default 100
+	// RetryMethods are the full names of idempotent methods, e.g. /wallet.Wallet/GetBalance.
+	RetryMethods []string `json:"retryMethods"`
 }
 
+// IdempotencyKeyMetadata marks a call the server deduplicates, so it may be retried.
+const IdempotencyKeyMetadata = "idempotency-key"
+
+const (
+	// like resty
+	defaultRetryWaitTime = 100 * time.Millisecond
+	maxRetryWaitTime     = 2 * time.Second
+)
+
 type RpcConnection struct {
 	options    Options
 	Connection *grpc.ClientConn
@@ -28,7 +46,11 @@ func (rpc *RpcConnection) CreateContext(parent context.Context, session *Session
 }
 
 func New(options Options) (rpc *RpcConnection, err error) {
-	connection, err := grpc.Dial(options.Address, grpc.WithInsecure(), grpc.WithUnaryInterceptor(clientInterceptor))
+	interceptors := []grpc.UnaryClientInterceptor{clientInterceptor}
+	if options.RetryCount > 0 {
+		interceptors = append([]grpc.UnaryClientInterceptor{retryInterceptor(options)}, interceptors...)
+	}
+	connection, err := grpc.Dial(options.Address, grpc.WithInsecure(), grpc.WithChainUnaryInterceptor(interceptors...))
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +82,56 @@ func clientInterceptor(ctx context.Context, method string, request interface{},
 	return err
 }
 
+func retryInterceptor(options Options) grpc.UnaryClientInterceptor {
+	methods := make(map[string]bool, len(options.RetryMethods))
+	for _, method := range options.RetryMethods {
+		methods[method] = true
+	}
+	waitTime := options.RetryWaitTime * time.Millisecond
+	if waitTime <= 0 {
+		waitTime = defaultRetryWaitTime
+	}
+	return func(ctx context.Context, method string, request, response interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var err error
+		for attempt := 0; attempt <= options.RetryCount; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-time.After(backoff(waitTime, attempt)):
+				case <-ctx.Done():
+					return err
+				}
+			}
+			err = invoker(ctx, method, request, response, cc, opts...)
+			if err == nil || ctx.Err() != nil || !Error.IsRetryable(err) || !retryable(ctx, methods, method) {
+				return err
+			}
+		}
+		return err
+	}
+}
+
+// retryable allows retries of the configured methods only, others may already have
+// run on the server, e.g. a transfer, unless the caller sent an idempotency key.
+func retryable(ctx context.Context, methods map[string]bool, method string) bool {
+	if methods[method] {
+		return true
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return len(md.Get(IdempotencyKeyMetadata)) > 0
+}
+
+// backoff doubles wait for every attempt up to maxRetryWaitTime, with jitter so
+// clients do not retry in step.
+func backoff(wait time.Duration, attempt int) time.Duration {
+	for i := 1; i < attempt && wait < maxRetryWaitTime; i++ {
+		wait *= 2
+	}
+	if wait > maxRetryWaitTime {
+		wait = maxRetryWaitTime
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
 //=========v2
 
 //package grpc
@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const checkMethod = "/grpc.health.v1.Health/Check"
+
+// flakyHealth fails with Unavailable until it has been called failures times.
+type flakyHealth struct {
+	grpc_health_v1.UnimplementedHealthServer
+	failures int32
+	calls    atomic.Int32
+}
+
+func (h *flakyHealth) Check(context.Context, *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if h.calls.Add(1) <= h.failures {
+		return nil, status.Error(codes.Unavailable, "warming up")
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+// dialRetry connects to a bufconn server with the retry interceptor of options.
+func dialRetry(t *testing.T, server *flakyHealth, options Options) grpc_health_v1.HealthClient {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(s, server)
+	go func() { _ = s.Serve(listener) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(retryInterceptor(options)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   Options
+		md        metadata.MD
+		wantCode  codes.Code
+		wantCalls int32
+	}{
+		{"retry method", Options{RetryCount: 3, RetryWaitTime: 1, RetryMethods: []string{checkMethod}}, nil, codes.OK, 3},
+		{"not idempotent", Options{RetryCount: 3, RetryWaitTime: 1}, nil, codes.Unavailable, 1},
+		{"idempotency key", Options{RetryCount: 3, RetryWaitTime: 1}, metadata.Pairs(IdempotencyKeyMetadata, "trx-1"), codes.OK, 3},
+		{"retries exhausted", Options{RetryCount: 1, RetryWaitTime: 1, RetryMethods: []string{checkMethod}}, nil, codes.Unavailable, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &flakyHealth{failures: 2}
+			client := dialRetry(t, server, tt.options)
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if tt.md != nil {
+				ctx = metadata.NewOutgoingContext(ctx, tt.md)
+			}
+			_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("Check() code = %v, want %v", got, tt.wantCode)
+			}
+			if got := server.calls.Load(); got != tt.wantCalls {
+				t.Errorf("calls = %v, want %v", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, time.Second, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := backoff(defaultRetryWaitTime, tt.attempt); got < tt.min || got > tt.max {
+			t.Errorf("backoff(%v) = %v, want between %v and %v", tt.attempt, got, tt.min, tt.max)
+		}
+	}
+}
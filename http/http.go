@@ -6,14 +6,19 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"time"
 
 	Error "github.com/ewinjuman/go-lib/error"
 	"github.com/ewinjuman/go-lib/helper/convert"
+	Logger "github.com/ewinjuman/go-lib/logger"
 	Session "github.com/ewinjuman/go-lib/session"
 	"github.com/go-resty/resty/v2"
 )
 
+// IdempotencyKeyHeader opts a POST or PATCH request in to retries.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
 type RestClient interface {
 	DefaultHeader(username, password string) http.Header
 	BasicAuth(username, password string) string
@@ -29,6 +34,33 @@ func New(options Options) RestClient {
 
 	httpClient.SetTimeout(options.Timeout * time.Second)
 	httpClient.SetDebug(options.DebugMode)
+	if options.DebugMode {
+		headers := Logger.NewHeaderPolicy(options.Headers)
+		httpClient.OnRequestLog(func(log *resty.RequestLog) error {
+			log.Header = headers.HTTPHeader(log.Header)
+			return nil
+		})
+		httpClient.OnResponseLog(func(log *resty.ResponseLog) error {
+			log.Header = headers.HTTPHeader(log.Header)
+			return nil
+		})
+	}
+
+	if options.RetryCount > 0 {
+		httpClient.SetRetryCount(options.RetryCount)
+		if options.RetryWaitTime > 0 {
+			httpClient.SetRetryWaitTime(options.RetryWaitTime * time.Millisecond)
+		}
+		httpClient.AddRetryCondition(func(r *resty.Response, err error) bool {
+			if r == nil || r.Request == nil || !retryable(r.Request) {
+				return false
+			}
+			if err != nil {
+				return Error.IsRetryable(err)
+			}
+			return r != nil && Error.IsRetryableCode(r.StatusCode())
+		})
+	}
 
 	return &client{
 		options:    options,
@@ -36,6 +68,16 @@ func New(options Options) RestClient {
 	}
 }
 
+// retryable allows retries of idempotent methods only, a POST or PATCH is retried
+// when the caller sets an Idempotency-Key header the upstream deduplicates on.
+func retryable(request *resty.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return request.Header.Get(IdempotencyKeyHeader) != ""
+}
+
 type client struct {
 	options    Options
 	httpClient *resty.Client
@@ -151,6 +193,13 @@ func (c *client) Execute(session *Session.Session, host string, path string, met
 		return body, statusCode, nil
 	}
 
+	// RFC 7807 problem document from upstream
+	if strings.HasPrefix(result.Header().Get("Content-Type"), Error.ProblemContentType) {
+		if problemErr, er := Error.ParseProblem(body); er == nil {
+			return body, statusCode, problemErr
+		}
+	}
+
 	return body, statusCode, errExecute
 }
 
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	Error "github.com/ewinjuman/go-lib/error"
+	Logger "github.com/ewinjuman/go-lib/logger"
+	Session "github.com/ewinjuman/go-lib/session"
+)
+
+func newTestSession() *Session.Session {
+	return Session.New(Logger.New(Logger.Options{Stdout: true}))
+}
+
+func TestClient_ExecuteRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		headers  http.Header
+		wantHits int32
+	}{
+		{"GET is retried", http.MethodGet, nil, 3},
+		{"PUT is retried", http.MethodPut, nil, 3},
+		{"POST is not retried", http.MethodPost, nil, 1},
+		{"PATCH is not retried", http.MethodPatch, nil, 1},
+		{"POST with idempotency key is retried", http.MethodPost, http.Header{IdempotencyKeyHeader: {"trx-1"}}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer server.Close()
+
+			client := New(Options{Timeout: 5, RetryCount: 2, RetryWaitTime: 1})
+			_, statusCode, _ := client.Execute(newTestSession(), server.URL, "/transfer", tt.method, tt.headers, map[string]interface{}{"amount": 10}, nil, nil)
+			if statusCode != http.StatusServiceUnavailable {
+				t.Errorf("Execute() statusCode = %v, want 503", statusCode)
+			}
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("requests = %v, want %v", got, tt.wantHits)
+			}
+		})
+	}
+}
+
+func TestClient_ExecuteProblem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", Error.ProblemContentType)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "limit reached",
+			"errorCode": 422,
+			"applicationStatus": "FAILED",
+			"businessCode": "WAL-1002",
+			"details": {"limit": "1000000"},
+			"errors": [{"field": "amount", "rule": "max", "message": "amount is too large"}]
+		}`))
+	}))
+	defer server.Close()
+
+	client := New(Options{Timeout: 5})
+	_, statusCode, err := client.Execute(newTestSession(), server.URL, "/transfer", http.MethodPost, nil, map[string]interface{}{"amount": 10}, nil, nil)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Errorf("Execute() statusCode = %v, want 422", statusCode)
+	}
+	var appErr *Error.ApplicationError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("Execute() error = %v, want *ApplicationError", err)
+	}
+	want := &Error.ApplicationError{ErrorCode: 422, Status: Error.FailedStatus, Message: "limit reached", BusinessCode: "WAL-1002", Details: map[string]string{"limit": "1000000"}}
+	if appErr.ErrorCode != want.ErrorCode || appErr.Status != want.Status || appErr.Message != want.Message ||
+		appErr.BusinessCode != want.BusinessCode || !reflect.DeepEqual(appErr.Details, want.Details) {
+		t.Errorf("Execute() error = %+v, want %+v", appErr, want)
+	}
+	validationErr, ok := Error.AsValidationError(err)
+	if !ok || len(validationErr.Fields) != 1 || validationErr.Fields[0].Field != "amount" {
+		t.Errorf("AsValidationError() = %+v, %v, want the amount field", validationErr, ok)
+	}
+}
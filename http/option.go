@@ -1,9 +1,20 @@
 package http
 
-import "time"
+import (
+	"time"
+
+	Logger "github.com/ewinjuman/go-lib/logger"
+)
 
 type Options struct {
 	Timeout   time.Duration `json:"timeout"`
 	DebugMode bool          `json:"debugMode"`
 	SkipTLS   bool          `json:"skipTLS"`
+	// RetryCount enables retries of errors classified by Error.IsRetryable, 0 disables it.
+	// Only GET, HEAD, OPTIONS, PUT and DELETE requests, or requests with an
+	// Idempotency-Key header, are retried.
+	RetryCount    int           `json:"retryCount"`
+	RetryWaitTime time.Duration `json:"retryWaitTime"` // in milliseconds
+	// Headers selects the headers dumped in DebugMode, the session Logger selects them for request logs.
+	Headers Logger.HeaderOption `json:"headers"`
 }
@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+type contextKey struct{}
+
+var (
+	defaultLogger     *Logger
+	defaultLoggerOnce sync.Once
+)
+
+// With returns a child Logger that adds fields to every entry. The parent is not
+// changed, so children can be used by concurrent requests. Level, files and
+// publishing are shared with the parent.
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	child := *l
+	if l.loggerSys != nil {
+		child.loggerSys = l.loggerSys.With(fields...)
+	}
+	return &child
+}
+
+// NewContext returns a copy of ctx carrying l.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the Logger stored by NewContext, or a stdout Logger when ctx has none.
+func FromContext(ctx context.Context) *Logger {
+	if l := FromContextOr(ctx, nil); l != nil {
+		return l
+	}
+	defaultLoggerOnce.Do(func() {
+		defaultLogger = New(Options{Stdout: true})
+	})
+	return defaultLogger
+}
+
+// FromContextOr returns the Logger stored by NewContext, or fallback when ctx has none.
+func FromContextOr(ctx context.Context, fallback *Logger) *Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(*Logger); ok && l != nil {
+			return l
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,62 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestLogger_With(t *testing.T) {
+	var out bytes.Buffer
+	parent := New(Options{Encoding: EncodingJSON, Sinks: []SinkOption{{Type: SinkWriter, Writer: &out}}})
+	parent.ThreadID = "parent"
+
+	child := parent.With(zap.String("request_id", "r-1"))
+	child.ThreadID = "child"
+	child.Info("from child")
+	parent.Info("from parent")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %v, want 2", lines)
+	}
+	if !strings.Contains(lines[0], `"request_id":"r-1"`) {
+		t.Errorf("child line = %s, want request_id", lines[0])
+	}
+	if strings.Contains(lines[1], "request_id") {
+		t.Errorf("parent line = %s, want no request_id", lines[1])
+	}
+	if parent.ThreadID != "parent" {
+		t.Errorf("parent ThreadID = %v, want unchanged", parent.ThreadID)
+	}
+
+	// the level is shared
+	_ = parent.SetLevel("error")
+	child.Info("dropped")
+	if got := strings.Count(out.String(), "\n"); got != 2 {
+		t.Errorf("lines after SetLevel = %v, want 2", got)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	l := New(Options{Stdout: true})
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want *Logger
+	}{
+		{"stored", NewContext(context.Background(), l), l},
+		{"missing", context.Background(), FromContext(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromContext(tt.ctx)
+			if got == nil || got != tt.want {
+				t.Errorf("FromContext() = %p, want %p", got, tt.want)
+			}
+		})
+	}
+}
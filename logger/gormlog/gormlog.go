@@ -0,0 +1,120 @@
+// Package gormlog writes GORM v2 logs through a go-lib Logger:
+//
+//	db, err := gorm.Open(dialector, &gorm.Config{
+//		Logger: gormlog.New(log, gormlog.Config{SlowThreshold: 200 * time.Millisecond}),
+//	})
+//
+// Queries run with db.WithContext(session.Context(ctx)) are logged with the request ID of the session.
+package gormlog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	Logger "github.com/ewinjuman/go-lib/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+const defaultSlowThreshold = 200 * time.Millisecond
+
+type Config struct {
+	// SlowThreshold logs slower queries as warnings, default 200ms. Use a negative value to disable it.
+	SlowThreshold time.Duration
+	// LogLevel is Silent, Error, Warn (default) or Info. Info logs every query.
+	LogLevel gormlogger.LogLevel
+	// IgnoreRecordNotFoundError does not log gorm.ErrRecordNotFound as an error.
+	IgnoreRecordNotFoundError bool
+}
+
+// Adapter implements gorm.io/gorm/logger.Interface.
+type Adapter struct {
+	logger *Logger.Logger
+	Config
+}
+
+var _ gormlogger.Interface = (*Adapter)(nil)
+
+func New(logger *Logger.Logger, config Config) *Adapter {
+	if config.SlowThreshold == 0 {
+		config.SlowThreshold = defaultSlowThreshold
+	}
+	if config.LogLevel == 0 {
+		config.LogLevel = gormlogger.Warn
+	}
+	return &Adapter{logger: logger, Config: config}
+}
+
+func (g *Adapter) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	clone := *g
+	clone.LogLevel = level
+	return &clone
+}
+
+func (g *Adapter) Info(ctx context.Context, message string, data ...interface{}) {
+	if g.LogLevel >= gormlogger.Info {
+		g.log(ctx, zapcore.InfoLevel, message, data...)
+	}
+}
+
+func (g *Adapter) Warn(ctx context.Context, message string, data ...interface{}) {
+	if g.LogLevel >= gormlogger.Warn {
+		g.log(ctx, zapcore.WarnLevel, message, data...)
+	}
+}
+
+func (g *Adapter) Error(ctx context.Context, message string, data ...interface{}) {
+	if g.LogLevel >= gormlogger.Error {
+		g.log(ctx, zapcore.ErrorLevel, message, data...)
+	}
+}
+
+// Trace logs failed queries as errors, slow queries as warnings and, at Info, every query.
+func (g *Adapter) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if g.LogLevel <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && g.LogLevel >= gormlogger.Error &&
+		(!g.IgnoreRecordNotFoundError || !errors.Is(err, gormlogger.ErrRecordNotFound)):
+		g.trace(ctx, zapcore.ErrorLevel, elapsed, fc, zap.Error(err))
+	case g.SlowThreshold > 0 && elapsed > g.SlowThreshold && g.LogLevel >= gormlogger.Warn:
+		g.trace(ctx, zapcore.WarnLevel, elapsed, fc,
+			zap.Bool("slow_query", true),
+			zap.Float64("slow_threshold", float64(g.SlowThreshold)/float64(time.Millisecond)),
+		)
+	case g.LogLevel >= gormlogger.Info:
+		g.trace(ctx, zapcore.InfoLevel, elapsed, fc)
+	}
+}
+
+func (g *Adapter) trace(ctx context.Context, level zapcore.Level, elapsed time.Duration, fc func() (string, int64), fields ...zap.Field) {
+	logger := g.loggerFrom(ctx)
+	sql, rows := fc()
+	fields = append([]zap.Field{
+		zap.String("request_id", logger.ThreadID),
+		zap.String("query", sql),
+		zap.Float64("duration", float64(elapsed)/float64(time.Millisecond)),
+		zap.Int64("affected-rows", rows),
+		zap.String("source", utils.FileWithLineNum()),
+	}, fields...)
+	logger.Log(level, "", fields...)
+}
+
+func (g *Adapter) log(ctx context.Context, level zapcore.Level, message string, data ...interface{}) {
+	logger := g.loggerFrom(ctx)
+	logger.Log(level, fmt.Sprintf(message, data...),
+		zap.String("request_id", logger.ThreadID),
+		zap.String("source", utils.FileWithLineNum()),
+	)
+}
+
+// loggerFrom prefers the request Logger in ctx, see Session.Context.
+func (g *Adapter) loggerFrom(ctx context.Context) *Logger.Logger {
+	return Logger.FromContextOr(ctx, g.logger)
+}
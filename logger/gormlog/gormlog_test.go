@@ -0,0 +1,130 @@
+package gormlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	Logger "github.com/ewinjuman/go-lib/logger"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func newLogger(out *bytes.Buffer) *Logger.Logger {
+	return Logger.New(Logger.Options{
+		Level:    "debug",
+		Encoding: Logger.EncodingJSON,
+		Sinks:    []Logger.SinkOption{{Type: Logger.SinkWriter, Writer: out}},
+	})
+}
+
+func entries(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var all []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unmarshal %s: %v", line, err)
+		}
+		all = append(all, entry)
+	}
+	return all
+}
+
+func TestAdapter_Trace(t *testing.T) {
+	sql := func() (string, int64) { return "SELECT * FROM users", 3 }
+	tests := []struct {
+		name      string
+		config    Config
+		elapsed   time.Duration
+		err       error
+		wantLevel string
+		wantField string
+	}{
+		{"error", Config{}, 0, errors.New("connection refused"), "ERROR", "error"},
+		{"record not found", Config{}, 0, gormlogger.ErrRecordNotFound, "ERROR", "error"},
+		{"record not found ignored", Config{IgnoreRecordNotFoundError: true}, 0, gormlogger.ErrRecordNotFound, "", ""},
+		{"slow query", Config{SlowThreshold: 10 * time.Millisecond}, 50 * time.Millisecond, nil, "WARN", "slow_query"},
+		{"slow query disabled", Config{SlowThreshold: -1}, time.Second, nil, "", ""},
+		{"fast query at warn", Config{}, 0, nil, "", ""},
+		{"fast query at info", Config{LogLevel: gormlogger.Info}, 0, nil, "INFO", "query"},
+		{"silent", Config{LogLevel: gormlogger.Silent}, 0, errors.New("connection refused"), "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			adapter := New(newLogger(&out), tt.config)
+			adapter.Trace(context.Background(), time.Now().Add(-tt.elapsed), sql, tt.err)
+
+			got := entries(t, &out)
+			if tt.wantLevel == "" {
+				if len(got) != 0 {
+					t.Errorf("entries = %v, want none", got)
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("entries = %v, want 1", got)
+			}
+			entry := got[0]
+			if entry["level"] != tt.wantLevel {
+				t.Errorf("level = %v, want %v", entry["level"], tt.wantLevel)
+			}
+			if _, ok := entry[tt.wantField]; !ok {
+				t.Errorf("entry = %v, want field %v", entry, tt.wantField)
+			}
+			if entry["query"] != "SELECT * FROM users" || entry["affected-rows"] != float64(3) {
+				t.Errorf("entry = %v, want query and rows", entry)
+			}
+		})
+	}
+}
+
+func TestAdapter_RequestIDFromContext(t *testing.T) {
+	var out bytes.Buffer
+	root := newLogger(&out)
+	child := root.With()
+	child.ThreadID = "req-42"
+	ctx := Logger.NewContext(context.Background(), child)
+
+	adapter := New(root, Config{LogLevel: gormlogger.Info})
+	adapter.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+	adapter.Info(ctx, "migrated %d tables", 2)
+
+	got := entries(t, &out)
+	if len(got) != 2 {
+		t.Fatalf("entries = %v, want 2", got)
+	}
+	for _, entry := range got {
+		if entry["request_id"] != "req-42" {
+			t.Errorf("request_id = %v, want req-42", entry["request_id"])
+		}
+	}
+	if got[1]["message"] != "migrated 2 tables" {
+		t.Errorf("message = %v", got[1]["message"])
+	}
+}
+
+func TestAdapter_LogMode(t *testing.T) {
+	var out bytes.Buffer
+	adapter := New(newLogger(&out), Config{})
+	silent := adapter.LogMode(gormlogger.Silent)
+
+	silent.Error(context.Background(), "hidden")
+	adapter.Warn(context.Background(), "shown")
+	adapter.Info(context.Background(), "below warn")
+
+	got := entries(t, &out)
+	if len(got) != 1 || got[0]["message"] != "shown" {
+		t.Errorf("entries = %v, want only the warning", got)
+	}
+	if adapter.LogLevel != gormlogger.Warn {
+		t.Errorf("LogMode changed the original adapter to %v", adapter.LogLevel)
+	}
+}
@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/ewinjuman/go-lib/helper/convert"
+)
+
+// DefaultMaskedHeaders carry credentials and are always masked.
+var DefaultMaskedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// HeaderOption selects the headers and gRPC metadata written by request logs.
+// Names are matched case-insensitively.
+type HeaderOption struct {
+	// Allow logs only these headers when set.
+	Allow []string `json:"allow"`
+	// Deny leaves headers out, it takes precedence over Allow.
+	Deny []string `json:"deny"`
+	// Mask logs headers as ******, in addition to DefaultMaskedHeaders.
+	Mask []string `json:"mask"`
+}
+
+// HeaderPolicy applies a HeaderOption.
+type HeaderPolicy struct {
+	allow map[string]bool
+	deny  map[string]bool
+	mask  map[string]bool
+}
+
+// NewHeaderPolicy compiles option.
+func NewHeaderPolicy(option HeaderOption) *HeaderPolicy {
+	return &HeaderPolicy{
+		allow: headerSet(option.Allow),
+		deny:  headerSet(option.Deny),
+		mask:  headerSet(append(append([]string(nil), DefaultMaskedHeaders...), option.Mask...)),
+	}
+}
+
+func headerSet(names []string) map[string]bool {
+	if len(names) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	return set
+}
+
+// value returns the value to log for the header name, false to leave it out.
+func (p *HeaderPolicy) value(name string, value interface{}) (interface{}, bool) {
+	name = strings.ToLower(name)
+	if p.deny[name] || (p.allow != nil && !p.allow[name]) {
+		return nil, false
+	}
+	if p.mask[name] {
+		return maskedString, true
+	}
+	return value, true
+}
+
+// HTTPHeader returns a copy of header following the policy.
+func (p *HeaderPolicy) HTTPHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	filtered := make(http.Header, len(header))
+	for name, values := range header {
+		if _, ok := p.value(name, nil); !ok {
+			continue
+		}
+		if p.mask[strings.ToLower(name)] {
+			values = []string{maskedString}
+		}
+		filtered[name] = values
+	}
+	return filtered
+}
+
+// Apply returns a copy of header following the policy. Header may be an http.Header,
+// gRPC metadata, "Name: value" lines such as a raw request header, or any value
+// encoding to a JSON object. Other values are logged as ****** since they may hold
+// credentials the policy cannot find.
+func (p *HeaderPolicy) Apply(header interface{}) interface{} {
+	if header == nil {
+		return nil
+	}
+	switch h := header.(type) {
+	case http.Header:
+		return p.HTTPHeader(h)
+	case string:
+		return p.headerLines(h)
+	case []byte:
+		return p.headerLines(string(h))
+	}
+	v := reflect.ValueOf(header)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		var decoded interface{}
+		convert.StringToObject(convert.ObjectToString(header), &decoded)
+		if _, ok := decoded.(map[string]interface{}); !ok {
+			return maskedString
+		}
+		v = reflect.ValueOf(decoded)
+	}
+	filtered := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		if value, ok := p.value(iter.Key().String(), iter.Value().Interface()); ok {
+			filtered[iter.Key().String()] = value
+		}
+	}
+	return filtered
+}
+
+// headerLines parses "Name: value" lines, the first line may be a request or status
+// line. Text that does not parse is masked as a whole.
+func (p *HeaderPolicy) headerLines(text string) interface{} {
+	header := http.Header{}
+	for i, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			if i == 0 && strings.Contains(line, "HTTP/") {
+				continue
+			}
+			return maskedString
+		}
+		header.Add(name, strings.TrimSpace(value))
+	}
+	if len(header) == 0 {
+		return maskedString
+	}
+	return p.HTTPHeader(header)
+}
+
+// Headers returns header following Options.Headers, e.g. for the header field of request logs.
+func (l *Logger) Headers(header interface{}) interface{} {
+	if l.headerPolicy != nil {
+		return l.headerPolicy.Apply(header)
+	}
+	return NewHeaderPolicy(l.Options.Headers).Apply(header)
+}
@@ -0,0 +1,78 @@
+package logger
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type testMetadata map[string][]string
+
+func TestHeaderPolicy_Apply(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	header.Set("Cookie", "session=abc")
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Api-Key", "key")
+	header.Set("X-Request-Id", "req-1")
+
+	tests := []struct {
+		name   string
+		option HeaderOption
+		header interface{}
+		want   interface{}
+	}{
+		{"secure default", HeaderOption{}, header, http.Header{
+			"Authorization": {"******"}, "Cookie": {"******"}, "Content-Type": {"application/json"},
+			"X-Api-Key": {"key"}, "X-Request-Id": {"req-1"},
+		}},
+		{"deny and mask", HeaderOption{Deny: []string{"content-type"}, Mask: []string{"x-api-key"}}, header, http.Header{
+			"Authorization": {"******"}, "Cookie": {"******"}, "X-Api-Key": {"******"}, "X-Request-Id": {"req-1"},
+		}},
+		{"allow", HeaderOption{Allow: []string{"Authorization", "X-Request-Id"}, Deny: []string{"x-request-id"}}, header, http.Header{
+			"Authorization": {"******"},
+		}},
+		{"grpc metadata", HeaderOption{}, testMetadata{"authorization": {"Bearer token"}, "request-id": {"req-1"}}, map[string]interface{}{
+			"authorization": "******", "request-id": []string{"req-1"},
+		}},
+		{"string map", HeaderOption{Deny: []string{"x-debug"}}, map[string]string{"set-cookie": "a=b", "x-debug": "1", "accept": "*/*"}, map[string]interface{}{
+			"set-cookie": "******", "accept": "*/*",
+		}},
+		{"struct", HeaderOption{}, struct {
+			Authorization string `json:"Authorization"`
+			Accept        string `json:"Accept"`
+		}{"Bearer token", "*/*"}, map[string]interface{}{"Authorization": "******", "Accept": "*/*"}},
+		{"header lines", HeaderOption{Deny: []string{"host"}}, "GET /v1/balance HTTP/1.1\r\nHost: api\r\nAuthorization: Bearer token\r\nAccept: */*\r\n\r\n", http.Header{
+			"Authorization": {"******"}, "Accept": {"*/*"},
+		}},
+		{"header line", HeaderOption{}, []byte("authorization: Bearer token"), http.Header{"Authorization": {"******"}}},
+		{"unparsable string", HeaderOption{}, "Bearer token", "******"},
+		{"not an object", HeaderOption{}, []string{"Authorization", "Bearer token"}, "******"},
+		{"nil", HeaderOption{}, nil, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewHeaderPolicy(tt.option).Apply(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+	if header.Get("Authorization") != "Basic dXNlcjpwYXNz" {
+		t.Error("Apply() changed the header")
+	}
+}
+
+func TestLogger_Headers(t *testing.T) {
+	header := map[string]string{"Authorization": "Bearer token", "X-Api-Key": "key"}
+	want := map[string]interface{}{"Authorization": "******", "X-Api-Key": "******"}
+
+	l := New(Options{Stdout: true, Headers: HeaderOption{Mask: []string{"X-Api-Key"}}})
+	if got := l.Headers(header); !reflect.DeepEqual(got, want) {
+		t.Errorf("Headers() = %v, want %v", got, want)
+	}
+	// a Logger created without New still uses Options.Headers
+	literal := &Logger{Options: Options{Headers: HeaderOption{Mask: []string{"X-Api-Key"}}}}
+	if got := literal.Headers(header); !reflect.DeepEqual(got, want) {
+		t.Errorf("Headers() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// ParseLevel converts debug, info, warn, error or fatal to a zapcore.Level.
+// Empty or unknown values fall back to info.
+func ParseLevel(level string) zapcore.Level {
+	l, err := parseLevel(level)
+	if err != nil {
+		return zapcore.InfoLevel
+	}
+	return l
+}
+
+// parseLevel is ParseLevel for configuration, an empty level is info and an unknown
+// one is an error.
+func parseLevel(level string) (zapcore.Level, error) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if level == "" {
+		return zapcore.InfoLevel, nil
+	}
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return l, fmt.Errorf("unknown level %q", level)
+	}
+	return l, nil
+}
+
+// Level returns the current minimum level.
+func (l *Logger) Level() zapcore.Level {
+	return l.level.Level()
+}
+
+// SetLevel changes the minimum level at runtime.
+func (l *Logger) SetLevel(level string) error {
+	return l.level.UnmarshalText([]byte(strings.ToLower(strings.TrimSpace(level))))
+}
+
+// LevelHandler serves the current level on GET and changes it on PUT, e.g.
+//
+//	curl -X PUT localhost:8080/log/level -d '{"level":"debug"}'
+func (l *Logger) LevelHandler() http.Handler {
+	return l.level
+}
+
+// ToggleLevelOnSignal switches between the configured level and level every time
+// one of signals is received, e.g. syscall.SIGUSR1. Call stop to release the signals.
+func (l *Logger) ToggleLevelOnSignal(level zapcore.Level, signals ...os.Signal) (stop func()) {
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, signals...)
+
+	original := l.Level()
+	go func() {
+		for {
+			select {
+			case <-ch:
+				if l.Level() == level {
+					l.level.SetLevel(original)
+				} else {
+					l.level.SetLevel(level)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
@@ -0,0 +1,54 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func waitLevel(t *testing.T, l *Logger, want zapcore.Level) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for l.Level() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Level() = %v, want %v", l.Level(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLogger_ToggleLevelOnSignal(t *testing.T) {
+	// keeps SIGUSR1 from terminating the test binary once stop unregisters the toggle
+	guard := make(chan os.Signal, 4)
+	signal.Notify(guard, syscall.SIGUSR1)
+	defer signal.Stop(guard)
+
+	l := New(Options{Stdout: true, Level: "info"})
+	stop := l.ToggleLevelOnSignal(zapcore.DebugLevel, syscall.SIGUSR1)
+
+	self, _ := os.FindProcess(os.Getpid())
+	_ = self.Signal(syscall.SIGUSR1)
+	waitLevel(t, l, zapcore.DebugLevel)
+	<-guard
+	_ = self.Signal(syscall.SIGUSR1)
+	waitLevel(t, l, zapcore.InfoLevel)
+	<-guard
+
+	stop()
+	_ = self.Signal(syscall.SIGUSR1)
+	select {
+	case <-guard:
+	case <-time.After(2 * time.Second):
+		t.Fatal("signal not received")
+	}
+	time.Sleep(20 * time.Millisecond)
+	if got := l.Level(); got != zapcore.InfoLevel {
+		t.Errorf("Level() after stop = %v, want %v", got, zapcore.InfoLevel)
+	}
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		level string
+		want  zapcore.Level
+	}{
+		{"debug", zapcore.DebugLevel},
+		{"INFO", zapcore.InfoLevel},
+		{" warn ", zapcore.WarnLevel},
+		{"error", zapcore.ErrorLevel},
+		{"fatal", zapcore.FatalLevel},
+		{"", zapcore.InfoLevel},
+		{"verbose", zapcore.InfoLevel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			if got := ParseLevel(tt.level); got != tt.want {
+				t.Errorf("ParseLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	l := New(Options{Stdout: true, Level: "warn"})
+	if l.loggerSys.Core().Enabled(zapcore.InfoLevel) {
+		t.Errorf("info enabled with level warn")
+	}
+
+	if err := l.SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel() error = %v", err)
+	}
+	if !l.loggerSys.Core().Enabled(zapcore.DebugLevel) {
+		t.Errorf("debug disabled after SetLevel(debug)")
+	}
+	if err := l.SetLevel("verbose"); err == nil {
+		t.Errorf("SetLevel() error = nil, want error")
+	}
+	if got := l.Level(); got != zapcore.DebugLevel {
+		t.Errorf("Level() = %v, want %v", got, zapcore.DebugLevel)
+	}
+}
+
+func TestLogger_LevelHandler(t *testing.T) {
+	l := New(Options{Stdout: true})
+	handler := l.LevelHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/log/level", strings.NewReader(`{"level":"error"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %v, want %v", rec.Code, http.StatusOK)
+	}
+	if got := l.Level(); got != zapcore.ErrorLevel {
+		t.Errorf("Level() = %v, want %v", got, zapcore.ErrorLevel)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/log/level", nil))
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"level":"error"}` {
+		t.Errorf("GET body = %v, want %v", got, `{"level":"error"}`)
+	}
+}
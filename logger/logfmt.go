@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder writes entries as key=value pairs. Time, level, caller and message
+// come first, the remaining fields follow sorted by key. Nested objects are
+// flattened with dots, arrays are written as JSON.
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg zapcore.EncoderConfig
+}
+
+func newLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{
+		MapObjectEncoder: zapcore.NewMapObjectEncoder(),
+		cfg:              cfg,
+	}
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+	return &logfmtEncoder{MapObjectEncoder: clone, cfg: e.cfg}
+}
+
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	enc := e.Clone().(*logfmtEncoder)
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	buf := logfmtPool.Get()
+	if e.cfg.TimeKey != "" && e.cfg.EncodeTime != nil {
+		writePair(buf, e.cfg.TimeKey, primitive(func(ae zapcore.ArrayEncoder) { e.cfg.EncodeTime(ent.Time, ae) }))
+	}
+	if e.cfg.LevelKey != "" && e.cfg.EncodeLevel != nil {
+		writePair(buf, e.cfg.LevelKey, primitive(func(ae zapcore.ArrayEncoder) { e.cfg.EncodeLevel(ent.Level, ae) }))
+	}
+	if e.cfg.CallerKey != "" && ent.Caller.Defined {
+		encodeCaller := e.cfg.EncodeCaller
+		if encodeCaller == nil {
+			encodeCaller = zapcore.ShortCallerEncoder
+		}
+		writePair(buf, e.cfg.CallerKey, primitive(func(ae zapcore.ArrayEncoder) { encodeCaller(ent.Caller, ae) }))
+	}
+	if e.cfg.MessageKey != "" {
+		writePair(buf, e.cfg.MessageKey, ent.Message)
+	}
+
+	flat := map[string]interface{}{}
+	flatten("", enc.Fields, flat)
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writePair(buf, k, flat[k])
+	}
+
+	if e.cfg.StacktraceKey != "" && ent.Stack != "" {
+		writePair(buf, e.cfg.StacktraceKey, ent.Stack)
+	}
+
+	lineEnding := e.cfg.LineEnding
+	if lineEnding == "" {
+		lineEnding = zapcore.DefaultLineEnding
+	}
+	buf.AppendString(lineEnding)
+	return buf, nil
+}
+
+// primitive runs an EncodeTime/EncodeLevel style encoder and returns the value it appended.
+func primitive(encode func(zapcore.ArrayEncoder)) interface{} {
+	m := zapcore.NewMapObjectEncoder()
+	_ = m.AddArray("v", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		encode(ae)
+		return nil
+	}))
+	if values, ok := m.Fields["v"].([]interface{}); ok && len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func flatten(prefix string, fields map[string]interface{}, out map[string]interface{}) {
+	for k, v := range fields {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok {
+			flatten(key, nested, out)
+			continue
+		}
+		out[key] = v
+	}
+}
+
+func writePair(buf *buffer.Buffer, key string, value interface{}) {
+	if buf.Len() > 0 {
+		buf.AppendByte(' ')
+	}
+	buf.AppendString(logfmtKey(key))
+	buf.AppendByte('=')
+	buf.AppendString(logfmtValue(value))
+}
+
+func logfmtKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func logfmtValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	case fmt.Stringer:
+		s = v.String()
+	case error:
+		s = v.Error()
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64:
+		return fmt.Sprint(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			s = fmt.Sprint(v)
+		} else {
+			s = string(b)
+		}
+	}
+	if needsQuote(s) {
+		return fmt.Sprintf("%q", s)
+	}
+	return s
+}
+
+func needsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestGetEncoder(t *testing.T) {
+	entry := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    time.Date(2024, 3, 1, 3, 4, 5, 0, time.UTC),
+		Message: "upstream slow",
+		Caller:  zapcore.NewEntryCaller(0, "/app/service/payment.go", 42, true),
+	}
+	fields := []zap.Field{
+		zap.String("request_id", "123"),
+		zap.Int("http_status", 200),
+		zap.Any("response", map[string]interface{}{"name": "ewin doe"}),
+		zap.Error(errors.New("timeout")),
+	}
+	tests := []struct {
+		name    string
+		options Options
+		want    string
+	}{
+		{
+			"json",
+			Options{Encoding: EncodingJSON},
+			`{"level":"WARN","time":"2024-03-01 10:04:05","message":"upstream slow","request_id":"123","http_status":200,"response":{"name":"ewin doe"},"error":"timeout"}` + "\n",
+		},
+		{
+			"json custom keys",
+			Options{Encoding: EncodingJSON, EncoderKeys: EncoderKeys{TimeKey: "@timestamp", LevelKey: "severity", MessageKey: "msg", CallerKey: "caller"}},
+			`{"severity":"WARN","@timestamp":"2024-03-01 10:04:05","caller":"service/payment.go:42","msg":"upstream slow","request_id":"123","http_status":200,"response":{"name":"ewin doe"},"error":"timeout"}` + "\n",
+		},
+		{
+			"json omit time",
+			Options{Encoding: EncodingJSON, EncoderKeys: EncoderKeys{TimeKey: "-"}},
+			`{"level":"WARN","message":"upstream slow","request_id":"123","http_status":200,"response":{"name":"ewin doe"},"error":"timeout"}` + "\n",
+		},
+		{
+			"logfmt",
+			Options{Encoding: EncodingLogfmt, EncoderKeys: EncoderKeys{CallerKey: "caller"}},
+			`time="2024-03-01 10:04:05" level=WARN caller=service/payment.go:42 message="upstream slow" error=timeout http_status=200 request_id=123 response.name="ewin doe"` + "\n",
+		},
+		{
+			"console",
+			Options{},
+			"2024-03-01 10:04:05\tWARN\tupstream slow\t" + `{"request_id": "123", "http_status": 200, "response": {"name":"ewin doe"}, "error": "timeout"}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := getEncoder(tt.options).EncodeEntry(entry, fields)
+			if err != nil {
+				t.Fatalf("EncodeEntry() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("EncodeEntry() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogfmtEncoder_With(t *testing.T) {
+	var out bytes.Buffer
+	core := zapcore.NewCore(getEncoder(Options{Encoding: EncodingLogfmt, EncoderKeys: EncoderKeys{TimeKey: "-"}}), zapcore.AddSync(&out), zapcore.DebugLevel)
+	logger := zap.New(core).With(zap.String("app", "wallet"))
+
+	logger.Info("first", zap.Strings("ids", []string{"a", "b"}))
+	logger.Info("second", zap.String("quote", `say "hi"`), zap.String("empty", ""))
+
+	want := `level=INFO message=first app=wallet ids="[\"a\",\"b\"]"` + "\n" +
+		`level=INFO message=second app=wallet empty="" quote="say \"hi\""` + "\n"
+	if got := out.String(); got != want {
+		t.Errorf("output =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -2,27 +2,27 @@ package logger
 
 import (
 	"fmt"
-	"os"
 	"runtime"
 	"strings"
 	"time"
 
-	"github.com/ewinjuman/go-lib/helper/convert"
-	"github.com/tidwall/gjson"
-	"github.com/tidwall/sjson"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-
-	RotateLogs "github.com/lestrrat-go/file-rotatelogs"
 )
 
 type Logger struct {
 	loggerSys *zap.Logger
+	level     zap.AtomicLevel
 	InstID    string
 	Options   Options
 	ThreadID  string
 	//CentralLogIsEnable      bool
-	//loggerTdr *zap.Logger
+	loggerTdr *zap.Logger
+	publisher *publisher
+	throttle  *throttle
+
+	maskingEngine *Masker
+	headerPolicy  *HeaderPolicy
 }
 type Fields map[string]interface{}
 
@@ -32,124 +32,196 @@ type Interface interface {
 	Error(message string, fields ...zap.Field)
 }
 
+// Printf logs GORM v1 style queries, use the gormlog package for GORM v2.
+// Arguments of unexpected types are logged as values instead of panicking.
 func (l *Logger) Printf(s string, v ...interface{}) {
 	if len(v) == 4 {
-		l.loggerSys.Info("",
-			zap.String("level", "INFO"),
-			zap.String("request_id", l.ThreadID),
-			zap.String("query", v[3].(string)),
-			zap.String("duration ", fmt.Sprintf("%.3fms", v[1].(float64))),
-			zap.Int64("affected-rows", v[2].(int64)),
-			zap.String("source", v[0].(string)),
-		)
-	} else {
-		l.loggerSys.Info("",
-			zap.String("level", "INFO"),
-			zap.String("request_id", l.ThreadID),
-			zap.Any("value", v),
-		)
+		source, sourceOK := v[0].(string)
+		duration, durationOK := v[1].(float64)
+		rows, rowsOK := v[2].(int64)
+		query, queryOK := v[3].(string)
+		if sourceOK && durationOK && rowsOK && queryOK {
+			l.loggerSys.Info("",
+				zap.String("request_id", l.ThreadID),
+				zap.String("query", query),
+				zap.String("duration ", fmt.Sprintf("%.3fms", duration)),
+				zap.Int64("affected-rows", rows),
+				zap.String("source", source),
+			)
+			return
+		}
 	}
+	l.loggerSys.Info("",
+		zap.String("request_id", l.ThreadID),
+		zap.Any("value", v),
+	)
 }
+
+// Print logs GORM v1 log calls, see Printf.
 func (l *Logger) Print(v ...interface{}) {
 	if len(v) < 2 {
 		return
 	}
-	switch v[0] {
-	case "sql":
-		delimiter := "/"
-		rightOfDelimiter := strings.Join(strings.Split(v[1].(string), delimiter)[4:], delimiter)
-		l.loggerSys.Info("",
-			zap.String("level", "INFO"),
-			zap.String("request_id", l.ThreadID),
-			zap.String("query", v[3].(string)),
-			zap.Any("values", v[4]),
-			zap.Float64("duration", float64(v[2].(time.Duration))/float64(time.Millisecond)),
-			zap.Int64("affected-rows", v[5].(int64)),
-			zap.String("source", rightOfDelimiter),
-		)
-	default:
-		delimiter := "/"
-		rightOfDelimiter := strings.Join(strings.Split(v[1].(string), delimiter)[4:], delimiter)
-		l.loggerSys.Info("",
-			zap.String("request_id", l.ThreadID),
-			zap.Any("values", v[2:]),
-			zap.String("source", rightOfDelimiter),
-		)
+	source, _ := v[1].(string)
+	if v[0] == "sql" && len(v) >= 6 {
+		duration, durationOK := v[2].(time.Duration)
+		query, queryOK := v[3].(string)
+		rows, rowsOK := v[5].(int64)
+		if durationOK && queryOK && rowsOK {
+			l.loggerSys.Info("",
+				zap.String("request_id", l.ThreadID),
+				zap.String("query", query),
+				zap.Any("values", v[4]),
+				zap.Float64("duration", float64(duration)/float64(time.Millisecond)),
+				zap.Int64("affected-rows", rows),
+				zap.String("source", trimSource(source)),
+			)
+			return
+		}
 	}
+	l.loggerSys.Info("",
+		zap.String("request_id", l.ThreadID),
+		zap.Any("values", v[2:]),
+		zap.String("source", trimSource(source)),
+	)
 }
 
-func getRotateWriter(config Options) zapcore.WriteSyncer {
-	if config.FileName == "" {
-		config.FileName = "log"
+// trimSource drops the first four path elements of a source file, keeping short paths as they are.
+func trimSource(source string) string {
+	const delimiter = "/"
+	parts := strings.Split(source, delimiter)
+	if len(parts) <= 4 {
+		return source
 	}
-	rotate, err := RotateLogs.New(
-		config.FileLocation+"%Y-%m-%d."+config.FileName,
-		RotateLogs.WithMaxAge(config.FileMaxAge*24*time.Hour),
-		RotateLogs.WithRotationTime(time.Hour),
-	)
+	return strings.Join(parts[4:], delimiter)
+}
+
+// New is like NewE but panics when the configuration is invalid.
+func New(config Options) *Logger {
+	l, err := NewE(config)
 	if err != nil {
 		panic(err)
 	}
-	return zapcore.AddSync(rotate)
+	return l
 }
 
-func New(config Options) *Logger {
-	var cores []zapcore.Core
-	var writer zapcore.WriteSyncer
+// NewE creates a Logger, returning an error when a level is unknown, or a sink, the
+// rotating files or publishing cannot be set up.
+func NewE(config Options) (*Logger, error) {
+	if _, err := LoadLocation(config.TimeZone); err != nil {
+		return nil, err
+	}
+	masking := config.Masking
+	masking.Paths = append(SplitMaskingPaths(config.MaskingLogJsonPath), masking.Paths...)
+	maskingEngine, err := NewMasker(masking)
+	if err != nil {
+		return nil, err
+	}
+	var scrubber *Scrubber
+	if config.Scrub != nil {
+		if scrubber, err = NewScrubber(*config.Scrub); err != nil {
+			return nil, err
+		}
+	}
+
+	minimum, err := parseLevel(config.Level)
+	if err != nil {
+		return nil, fmt.Errorf("logger: %w", err)
+	}
+	level := zap.NewAtomicLevelAt(minimum)
+	cores, err := buildCores(config, level)
+	if err != nil {
+		return nil, err
+	}
 
-	if config.Stdout {
-		writer = zapcore.AddSync(os.Stdout)
-	} else {
-		writer = getRotateWriter(config)
+	var (
+		publisher  *publisher
+		publishTdr zapcore.Core
+	)
+	if config.PublishLog {
+		publisher, err = newPublisher(config.Publish)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(config.Publish.Stream) {
+		case PublishStreamTdr:
+			publishTdr = publisher.core(tdrEncoder(config), zapcore.DebugLevel)
+		case PublishStreamAll:
+			publishTdr = publisher.core(tdrEncoder(config), zapcore.DebugLevel)
+			cores = append(cores, publisher.core(getEncoder(publishEncoderOptions(config)), level))
+		default:
+			cores = append(cores, publisher.core(getEncoder(publishEncoderOptions(config)), level))
+		}
 	}
 
-	core := zapcore.NewCore(getEncoder(), writer, zapcore.InfoLevel)
-	cores = append(cores, core)
+	loggerTdr, err := newTdrLogger(config, publishTdr)
+	if err != nil {
+		if publisher != nil {
+			_ = publisher.Close()
+		}
+		return nil, err
+	}
 
 	combinedCore := zapcore.NewTee(cores...)
+	if scrubber != nil {
+		combinedCore = &scrubCore{Core: combinedCore, scrubber: scrubber}
+	}
+	throttler := newThrottle(config)
+	if throttler != nil {
+		combinedCore = &throttleCore{Core: combinedCore, throttle: throttler}
+	}
 
+	// skip the Logger method so the caller is the code calling the Logger
 	loggerSys := zap.New(combinedCore,
-		zap.AddCallerSkip(3),
+		zap.AddCallerSkip(1),
 		zap.AddCaller(),
 	)
 
 	l := &Logger{
-		loggerSys: loggerSys,
+		loggerSys:     loggerSys,
+		loggerTdr:     loggerTdr,
+		publisher:     publisher,
+		throttle:      throttler,
+		level:         level,
+		maskingEngine: maskingEngine,
+		headerPolicy:  NewHeaderPolicy(config.Headers),
 	}
 	l.Options = config
-	return l
+	return l, nil
 }
 
-type LogTdrModel struct {
-	AppName        string      `json:"app"`
-	AppVersion     string      `json:"ver"`
-	IP             string      `json:"ip"`
-	Port           int         `json:"port"`
-	SrcIP          string      `json:"srcIP"`
-	RespTime       int64       `json:"rt"`
-	Path           string      `json:"path"`
-	Header         interface{} `json:"header"`
-	Request        interface{} `json:"req"`
-	Response       interface{} `json:"resp"`
-	Error          string      `json:"error"`
-	ThreadID       string      `json:"threadID"`
-	AdditionalData interface{} `json:"addData"`
-}
-
-func getEncoder() zapcore.Encoder {
+func getEncoder(options Options) zapcore.Encoder {
+	keys := options.EncoderKeys
 	config := zapcore.EncoderConfig{
-		TimeKey:        "time",
-		MessageKey:     "message",
+		TimeKey:        encoderKey(keys.TimeKey, "time"),
+		LevelKey:       encoderKey(keys.LevelKey, "level"),
+		MessageKey:     encoderKey(keys.MessageKey, "message"),
+		CallerKey:      encoderKey(keys.CallerKey, ""),
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
 		EncodeDuration: MillisDurationEncoder,
-		EncodeTime:     TDRLogTimeEncoder,
+		EncodeTime:     timeEncoder(options),
 		LineEnding:     zapcore.DefaultLineEnding,
 	}
-	return zapcore.NewConsoleEncoder(config)
+	switch strings.ToLower(options.Encoding) {
+	case EncodingJSON:
+		return zapcore.NewJSONEncoder(config)
+	case EncodingLogfmt:
+		return newLogfmtEncoder(config)
+	default:
+		return zapcore.NewConsoleEncoder(config)
+	}
 }
 
-func TDRLogTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-	location, _ := time.LoadLocation("Asia/Jakarta")
-	enc.AppendString(t.In(location).Format("2006-01-02 15:04:05.999"))
+func encoderKey(key, defaultKey string) string {
+	switch key {
+	case "":
+		return defaultKey
+	case "-":
+		return zapcore.OmitKey
+	default:
+		return key
+	}
 }
 
 func MillisDurationEncoder(d time.Duration, enc zapcore.PrimitiveArrayEncoder) {
@@ -166,59 +238,49 @@ func (l *Logger) InfoSys(message string, fields ...zap.Field) {
 	l.loggerSys.Info(message, fields...)
 }
 
-func (l *Logger) MaskingJson(data interface{}) interface{} {
-	jsonString := convert.ObjectToString(data)
-	path := strings.Split(l.Options.MaskingLogJsonPath, "|")
-
-	for _, key := range path {
-		value := gjson.Get(jsonString, key)
-		if value.String() != "" && value.Type.String() != "Null" {
-			switch value.Type.String() {
-			case "String":
-				jsonString, _ = sjson.Set(jsonString, key, "******")
-			case "JSON":
-				jsonString, _ = sjson.Set(jsonString, key, "***Mask JSON***")
-			case "False":
-			case "True":
-			default:
-				jsonString, _ = sjson.Set(jsonString, key, 00000)
-			}
-		}
-	}
+func (l *Logger) Debug(message string, fields ...zap.Field) {
+	l.loggerSys.Debug(message, fields...)
+}
 
-	var mapData interface{}
-	convert.StringToObject(jsonString, &mapData)
-	return mapData
+func (l *Logger) Info(message string, fields ...zap.Field) {
+	l.loggerSys.Info(message, fields...)
 }
 
-func (l *Logger) MaskingJsonWithPath(data interface{}, jsonPath string) interface{} {
-	jsonString := convert.ObjectToString(data)
-	path := strings.Split(jsonPath, "|")
-
-	for _, key := range path {
-		value := gjson.Get(jsonString, key)
-		if value.String() != "" && value.Type.String() != "Null" {
-			switch value.Type.String() {
-			case "String":
-				jsonString, _ = sjson.Set(jsonString, key, "******")
-			case "JSON":
-				jsonString, _ = sjson.Set(jsonString, key, "***Mask JSON***")
-			case "False":
-			case "True":
-			default:
-				jsonString, _ = sjson.Set(jsonString, key, 00000)
-			}
-		}
+func (l *Logger) Warn(message string, fields ...zap.Field) {
+	l.loggerSys.Warn(message, fields...)
+}
+
+// Fatal logs the message and then calls os.Exit(1).
+func (l *Logger) Fatal(message string, fields ...zap.Field) {
+	l.loggerSys.Fatal(message, fields...)
+}
+
+// Log writes the message at the given level without adding the caller to the message like Error does.
+func (l *Logger) Log(level zapcore.Level, message string, fields ...zap.Field) {
+	l.LogDepth(1, level, message, fields...)
+}
+
+// LogDepth is like Log but reports the caller depth frames above the caller of LogDepth,
+// for helpers such as Session that wrap the Logger.
+func (l *Logger) LogDepth(depth int, level zapcore.Level, message string, fields ...zap.Field) {
+	logger := l.loggerSys
+	if callerKey := l.Options.EncoderKeys.CallerKey; depth > 0 && callerKey != "" && callerKey != "-" {
+		logger = logger.WithOptions(zap.AddCallerSkip(depth))
+	}
+	if ce := logger.Check(level, message); ce != nil {
+		ce.Write(fields...)
 	}
+}
 
-	var mapData interface{}
-	convert.StringToObject(jsonString, &mapData)
-	return mapData
+// MaskingJson masks the paths of Options.MaskingLogJsonPath and Options.Masking, see MaskingOption.
+func (l *Logger) MaskingJson(data interface{}) interface{} {
+	return l.masker().Mask(data)
 }
 
-//func (l *Logger) InfoTdr(message string, fields ...zap.Field) {
-//	l.loggerTdr.Info(message, fields...)
-//}
+// MaskingJsonWithPath masks jsonPath, a MaskingLogJsonPath style list such as "pin|items.#.cardNumber".
+func (l *Logger) MaskingJsonWithPath(data interface{}, jsonPath string) interface{} {
+	return maskerFor(jsonPath).Mask(data)
+}
 
 //func (l *Logger) MaskingData(jsonByte []byte) (jsonString string) {
 //	b := new(bytes.Buffer)
@@ -85,3 +85,27 @@ func TestLogger_MaskingJsonWithPath(t *testing.T) {
 		})
 	}
 }
+
+func TestLogger_PrintUnexpectedArguments(t *testing.T) {
+	l := New(Options{Stdout: true})
+	tests := []struct {
+		name string
+		call func()
+	}{
+		{"printf wrong types", func() { l.Printf("%v", 1, "2", 3, 4) }},
+		{"printf gorm v1", func() { l.Printf("%v", "file.go:1", 1.5, int64(2), "SELECT 1") }},
+		{"print sql wrong types", func() { l.Print("sql", "short/path", "1ms", 2, nil, "rows") }},
+		{"print sql too short", func() { l.Print("sql", "a/b/c/d/e/f.go") }},
+		{"print source not a string", func() { l.Print("log", 42, "value") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("panic: %v", r)
+				}
+			}()
+			tt.call()
+		})
+	}
+}
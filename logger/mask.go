@@ -0,0 +1,269 @@
+package logger
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ewinjuman/go-lib/helper/convert"
+)
+
+const (
+	maskedString = "******"
+	maskedJSON   = "***Mask JSON***"
+)
+
+// MaskingOption selects the values masked in request, response and body logs.
+type MaskingOption struct {
+	// Paths are dot separated, matched case-insensitively:
+	//	data.pin            exact path
+	//	items.#.cardNumber  # matches every array item
+	//	data.*.token        * matches any single key or array item
+	//	**.password         ** matches any number of keys, including none
+	Paths []string `json:"paths"`
+	// Keys are masked at any depth, "pin" is the same as the path "**.pin".
+	Keys []string `json:"keys"`
+	// Rules mask a path with a strategy other than the default. They take precedence
+	// over Paths and Keys, and the first matching rule wins.
+	Rules []MaskingRule `json:"rules"`
+	// HashSalt is prepended to values masked with MaskHash.
+	HashSalt string `json:"hashSalt"`
+}
+
+// Masker masks values by path. By default strings become ******, objects and arrays
+// ***Mask JSON***, numbers 0. Booleans, null and empty strings are kept.
+type Masker struct {
+	rules []maskRule
+	// digits is set by strategies that keep digits of numbers, which are then decoded
+	// as json.Number so long numbers such as a PAN keep their precision.
+	digits bool
+}
+
+type maskRule struct {
+	segments []string
+	mask     maskFunc
+}
+
+// NewMasker compiles option, MaskingLogJsonPath can be added with SplitMaskingPaths.
+// It fails on an unknown strategy.
+func NewMasker(option MaskingOption) (*Masker, error) {
+	m := &Masker{}
+	for _, rule := range option.Rules {
+		mask, err := rule.maskFunc(option.HashSalt)
+		if err != nil {
+			return nil, err
+		}
+		m.add(splitPath(rule.Path), mask)
+		switch strings.ToLower(rule.Strategy) {
+		case "", MaskDefault, MaskRedact:
+		default:
+			m.digits = true
+		}
+	}
+	for _, path := range option.Paths {
+		m.add(splitPath(path), maskValue)
+	}
+	for _, key := range option.Keys {
+		if key = strings.TrimSpace(key); key != "" {
+			m.add([]string{"**", strings.ToLower(key)}, maskValue)
+		}
+	}
+	return m, nil
+}
+
+func (m *Masker) add(segments []string, mask maskFunc) {
+	if len(segments) > 0 {
+		m.rules = append(m.rules, maskRule{segments: segments, mask: mask})
+	}
+}
+
+// SplitMaskingPaths splits a MaskingLogJsonPath value such as "pin|data.token".
+func SplitMaskingPaths(paths string) []string {
+	if paths == "" {
+		return nil
+	}
+	return strings.Split(paths, "|")
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(strings.TrimSpace(path), ".") {
+		if segment != "" {
+			segments = append(segments, strings.ToLower(segment))
+		}
+	}
+	return segments
+}
+
+// Mask returns a masked copy of data decoded from JSON, data itself is not changed.
+func (m *Masker) Mask(data interface{}) interface{} {
+	return m.maskFrom(data, m.start())
+}
+
+// maskFrom masks data found where the rules are in states, see Logger.MaskedData.
+func (m *Masker) maskFrom(data interface{}, states []state) interface{} {
+	var value interface{}
+	if !m.digits {
+		convert.StringToObject(convert.ObjectToString(data), &value)
+		return m.walk(value, states)
+	}
+	decoder := json.NewDecoder(strings.NewReader(convert.ObjectToString(data)))
+	decoder.UseNumber()
+	_ = decoder.Decode(&value)
+	// numbers that were not masked are float64 as without rules
+	return numbersToFloat(m.walk(value, states))
+}
+
+func numbersToFloat(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, child := range v {
+			v[key] = numbersToFloat(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = numbersToFloat(child)
+		}
+	}
+	return value
+}
+
+// state is a rule and the index of its next segment.
+type state struct {
+	rule, next int
+}
+
+func (m *Masker) start() []state {
+	states := make([]state, 0, len(m.rules))
+	for i := range m.rules {
+		states = append(states, state{rule: i})
+	}
+	return m.closure(states)
+}
+
+// closure adds the states that skip a ** matching no key.
+func (m *Masker) closure(states []state) []state {
+	for i := 0; i < len(states); i++ {
+		s := states[i]
+		segments := m.rules[s.rule].segments
+		if s.next < len(segments) && segments[s.next] == "**" {
+			states = append(states, state{rule: s.rule, next: s.next + 1})
+		}
+	}
+	return states
+}
+
+// step returns the states after matching key, and the first rule matching it completely.
+func (m *Masker) step(states []state, key string, index bool) ([]state, *maskRule) {
+	var (
+		next    []state
+		matched = -1
+	)
+	for _, s := range states {
+		segments := m.rules[s.rule].segments
+		if s.next >= len(segments) {
+			continue
+		}
+		segment := segments[s.next]
+		switch {
+		case segment == "**":
+			next = append(next, s)
+		case segment == "*",
+			segment == "#" && index,
+			strings.EqualFold(segment, key):
+			if s.next+1 < len(segments) {
+				next = append(next, state{rule: s.rule, next: s.next + 1})
+			} else if matched < 0 || s.rule < matched {
+				matched = s.rule
+			}
+		}
+	}
+	if matched >= 0 {
+		return nil, &m.rules[matched]
+	}
+	return m.closure(next), nil
+}
+
+func (m *Masker) walk(value interface{}, states []state) interface{} {
+	if len(states) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			next, rule := m.step(states, key, false)
+			if rule != nil {
+				v[key] = rule.mask(child)
+				continue
+			}
+			v[key] = m.walk(child, next)
+		}
+	case []interface{}:
+		for i, child := range v {
+			next, rule := m.step(states, strconv.Itoa(i), true)
+			if rule != nil {
+				v[i] = rule.mask(child)
+				continue
+			}
+			v[i] = m.walk(child, next)
+		}
+	}
+	return value
+}
+
+func maskValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return v
+		}
+		return maskedString
+	case map[string]interface{}, []interface{}:
+		return maskedJSON
+	case float64, json.Number:
+		return float64(0)
+	default:
+		// bool and null are kept
+		return v
+	}
+}
+
+// maxCachedMaskers bounds the cache of maskerFor, paths passed to MaskingJsonWithPath
+// come from callers and may be built per request.
+const maxCachedMaskers = 256
+
+var (
+	maskers      sync.Map
+	maskersCount atomic.Int64
+)
+
+// maskerFor compiles paths once and reuses the Masker while the cache has room.
+func maskerFor(paths string) *Masker {
+	if m, ok := maskers.Load(paths); ok {
+		return m.(*Masker)
+	}
+	// paths without rules always compile
+	masker, _ := NewMasker(MaskingOption{Paths: SplitMaskingPaths(paths)})
+	if maskersCount.Load() >= maxCachedMaskers {
+		return masker
+	}
+	m, loaded := maskers.LoadOrStore(paths, masker)
+	if !loaded {
+		maskersCount.Add(1)
+	}
+	return m.(*Masker)
+}
+
+// masker returns the Masker built by NewE, or one compiled from MaskingLogJsonPath
+// for a Logger created without New.
+func (l *Logger) masker() *Masker {
+	if l.maskingEngine != nil {
+		return l.maskingEngine
+	}
+	return maskerFor(l.Options.MaskingLogJsonPath)
+}
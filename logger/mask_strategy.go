@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Masking strategies of MaskingRule.
+const (
+	// MaskDefault is the MaskingJson behaviour: ******, ***Mask JSON*** or 0.
+	MaskDefault = "default"
+	// MaskRedact replaces any value but null with [REDACTED].
+	MaskRedact = "redact"
+	// MaskKeep keeps the First and Last characters, e.g. ab****yz.
+	MaskKeep = "keep"
+	// MaskEmail keeps the first character of the local part and the domain, e.g. j*******@example.com.
+	MaskEmail = "email"
+	// MaskPAN keeps the first 6 and last 4 digits of a card number, e.g. 411111******1111.
+	MaskPAN = "pan"
+	// MaskNIK keeps the 6 digit region code of an Indonesian NIK, e.g. 320101**********.
+	MaskNIK = "nik"
+	// MaskPhone keeps the country or trunk prefix, the operator code and the last 4 digits,
+	// e.g. 0812****7890 or +62812****7890.
+	MaskPhone = "phone"
+	// MaskHash replaces the value with sha256:<hex> of HashSalt and the value, so equal
+	// values can still be correlated.
+	MaskHash = "hash"
+)
+
+const (
+	maskChar = '*'
+	redacted = "[REDACTED]"
+)
+
+// MaskingRule masks Path, see MaskingOption.Paths, with Strategy.
+type MaskingRule struct {
+	Path     string `json:"path"`
+	Strategy string `json:"strategy"`
+	// First and Last are the characters kept by MaskKeep.
+	First int `json:"first"`
+	Last  int `json:"last"`
+}
+
+type maskFunc func(value interface{}) interface{}
+
+func (r MaskingRule) maskFunc(salt string) (maskFunc, error) {
+	switch strings.ToLower(r.Strategy) {
+	case "", MaskDefault:
+		return maskValue, nil
+	case MaskRedact:
+		return redact, nil
+	case MaskKeep:
+		first, last := r.First, r.Last
+		return maskString(func(s string) string { return keep(s, first, last) }), nil
+	case MaskEmail:
+		return maskString(maskEmail), nil
+	case MaskPAN:
+		return maskString(func(s string) string { return keepDigits(s, 6, 4) }), nil
+	case MaskNIK:
+		return maskString(func(s string) string { return keepDigits(s, 6, 0) }), nil
+	case MaskPhone:
+		return maskString(maskPhone), nil
+	case MaskHash:
+		return maskString(func(s string) string {
+			sum := sha256.Sum256([]byte(salt + s))
+			return "sha256:" + hex.EncodeToString(sum[:])
+		}), nil
+	default:
+		return nil, fmt.Errorf("logger: masking %s: unknown strategy %s", r.Path, r.Strategy)
+	}
+}
+
+// maskString applies mask to strings and numbers, objects and arrays are masked like MaskDefault.
+func maskString(mask func(string) string) maskFunc {
+	return func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				return v
+			}
+			return mask(v)
+		case json.Number:
+			return mask(v.String())
+		case float64:
+			return mask(strconv.FormatFloat(v, 'f', -1, 64))
+		default:
+			return maskValue(v)
+		}
+	}
+}
+
+func redact(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return redacted
+}
+
+// keep masks everything but the first and last characters. Values too short to
+// hide anything are masked completely.
+func keep(s string, first, last int) string {
+	runes := []rune(s)
+	if first < 0 {
+		first = 0
+	}
+	if last < 0 {
+		last = 0
+	}
+	if first+last >= len(runes) {
+		return strings.Repeat(string(maskChar), len(runes))
+	}
+	for i := first; i < len(runes)-last; i++ {
+		runes[i] = maskChar
+	}
+	return string(runes)
+}
+
+// keepDigits is keep counting digits only, so separators such as spaces and dashes stay.
+// Values without digits are masked completely.
+func keepDigits(s string, first, last int) string {
+	digits := 0
+	for _, r := range s {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	if digits == 0 {
+		return keep(s, 0, 0)
+	}
+	if first+last >= digits {
+		first, last = 0, 0
+	}
+	runes := []rune(s)
+	position := 0
+	for i, r := range runes {
+		if !unicode.IsDigit(r) {
+			continue
+		}
+		if position >= first && position < digits-last {
+			runes[i] = maskChar
+		}
+		position++
+	}
+	return string(runes)
+}
+
+func maskEmail(s string) string {
+	at := strings.LastIndexByte(s, '@')
+	if at <= 0 {
+		return keep(s, 1, 0)
+	}
+	return keep(s[:at], 1, 0) + s[at:]
+}
+
+func maskPhone(s string) string {
+	// 62 and the operator code, e.g. +62812, otherwise the trunk prefix and operator code, e.g. 0812
+	first := 4
+	if strings.HasPrefix(s, "+62") || strings.HasPrefix(s, "62") {
+		first = 5
+	}
+	return keepDigits(s, first, 4)
+}
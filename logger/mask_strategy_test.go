@@ -0,0 +1,125 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestMaskingRule_Strategies(t *testing.T) {
+	salted := sha256.Sum256([]byte("pepper" + "081234567890"))
+	tests := []struct {
+		name  string
+		rule  MaskingRule
+		value interface{}
+		want  interface{}
+	}{
+		{"default string", MaskingRule{}, "secret", "******"},
+		{"default number", MaskingRule{Strategy: MaskDefault}, float64(12), float64(0)},
+		{"redact", MaskingRule{Strategy: MaskRedact}, true, "[REDACTED]"},
+		{"redact object", MaskingRule{Strategy: MaskRedact}, map[string]interface{}{"a": "b"}, "[REDACTED]"},
+		{"redact null", MaskingRule{Strategy: MaskRedact}, nil, nil},
+		{"keep first and last", MaskingRule{Strategy: MaskKeep, First: 2, Last: 2}, "abcdefgh", "ab****gh"},
+		{"keep too short", MaskingRule{Strategy: MaskKeep, First: 2, Last: 2}, "abcd", "****"},
+		{"keep unicode", MaskingRule{Strategy: MaskKeep, Last: 1}, "héllo", "****o"},
+		{"email", MaskingRule{Strategy: MaskEmail}, "john.doe@example.com", "j*******@example.com"},
+		{"not an email", MaskingRule{Strategy: MaskEmail}, "johndoe", "j******"},
+		{"pan", MaskingRule{Strategy: MaskPAN}, "4111111111111111", "411111******1111"},
+		{"pan with separators", MaskingRule{Strategy: MaskPAN}, "4111 1111 1111 1111", "4111 11** **** 1111"},
+		{"pan without digits", MaskingRule{Strategy: MaskPAN}, "N/A", "***"},
+		{"nik", MaskingRule{Strategy: MaskNIK}, "3201011234560001", "320101**********"},
+		{"nik number", MaskingRule{Strategy: MaskNIK}, float64(3201011234560001), "320101**********"},
+		{"phone local", MaskingRule{Strategy: MaskPhone}, "081234567890", "0812****7890"},
+		{"phone international", MaskingRule{Strategy: MaskPhone}, "+6281234567890", "+62812****7890"},
+		{"phone short", MaskingRule{Strategy: MaskPhone}, "0812345", "*******"},
+		{"hash", MaskingRule{Strategy: MaskHash}, "081234567890", "sha256:" + hex.EncodeToString(sha(t, "081234567890"))},
+		{"hash keeps empty", MaskingRule{Strategy: MaskHash}, "", ""},
+		{"partial strategy on object", MaskingRule{Strategy: MaskPAN}, []interface{}{"4111"}, "***Mask JSON***"},
+		{"partial strategy on bool", MaskingRule{Strategy: MaskPhone}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mask, err := tt.rule.maskFunc("")
+			if err != nil {
+				t.Fatalf("maskFunc() error = %v", err)
+			}
+			if got := mask(tt.value); got != tt.want {
+				t.Errorf("mask(%v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	mask, _ := MaskingRule{Strategy: MaskHash}.maskFunc("pepper")
+	if got, want := mask("081234567890"), "sha256:"+hex.EncodeToString(salted[:]); got != want {
+		t.Errorf("salted hash = %v, want %v", got, want)
+	}
+}
+
+func sha(t *testing.T, s string) []byte {
+	t.Helper()
+	sum := sha256.Sum256([]byte(s))
+	return sum[:]
+}
+
+func TestNewMasker_Rules(t *testing.T) {
+	masker, err := NewMasker(MaskingOption{
+		Keys: []string{"phone"},
+		Rules: []MaskingRule{
+			{Path: "**.phone", Strategy: MaskPhone},
+			{Path: "items.#.card", Strategy: MaskPAN},
+			{Path: "email", Strategy: MaskEmail},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := masker.Mask(map[string]interface{}{
+		"email": "jane@example.com",
+		"user":  map[string]interface{}{"phone": "081234567890"},
+		"items": []interface{}{map[string]interface{}{"card": "5500000000000004"}},
+	}).(map[string]interface{})
+
+	if got["email"] != "j***@example.com" {
+		t.Errorf("email = %v", got["email"])
+	}
+	// the rule wins over the key
+	if phone := got["user"].(map[string]interface{})["phone"]; phone != "0812****7890" {
+		t.Errorf("phone = %v", phone)
+	}
+	if card := got["items"].([]interface{})[0].(map[string]interface{})["card"]; card != "550000******0004" {
+		t.Errorf("card = %v", card)
+	}
+
+	if _, err := NewMasker(MaskingOption{Rules: []MaskingRule{{Path: "pin", Strategy: "scramble"}}}); err == nil {
+		t.Errorf("NewMasker() error = nil, want unknown strategy")
+	}
+}
+
+func TestNewMasker_RulesLongNumbers(t *testing.T) {
+	masker, err := NewMasker(MaskingOption{
+		Paths: []string{"pin"},
+		Rules: []MaskingRule{
+			{Path: "card", Strategy: MaskPAN},
+			{Path: "account", Strategy: MaskKeep, Last: 4},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := masker.Mask(map[string]interface{}{
+		"card":    uint64(6062828888666688897),
+		"account": int64(9007199254740993),
+		"pin":     123456,
+		"amount":  1000,
+	})
+	want := map[string]interface{}{
+		"card":    "606282*********8897",
+		"account": "************0993",
+		"pin":     float64(0),
+		"amount":  float64(1000),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Mask() = %v, want %v", got, want)
+	}
+}
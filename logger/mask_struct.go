@@ -0,0 +1,457 @@
+package logger
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Tagged struct fields are masked while they are encoded, without a JSON round trip:
+//
+//	type Request struct {
+//		Phone   string `json:"phone" log:"mask,phone"`
+//		Account string `json:"account" log:"mask,keep=4"`
+//		Pin     string `json:"pin" log:"mask"`
+//		Photo   []byte `json:"photo" log:"-"`
+//	}
+//
+// log:"-" leaves the field out, log:"mask" masks it like MaskDefault. After mask a
+// strategy (redact, email, pan, nik, phone, hash) or keep=N, first=N and last=N
+// choose partial masking, keep=N being the same as last=N. Fields are named after
+// their json tag.
+
+const logTag = "log"
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	// maskedContainer stands for structs, maps and slices passed to a mask function.
+	maskedContainer = map[string]interface{}{}
+)
+
+type structPlan struct {
+	fields []fieldPlan
+	// walk is true when the struct has log tags, or fields that may hold tagged values.
+	walk bool
+}
+
+type fieldPlan struct {
+	index     int
+	name      string
+	omitEmpty bool
+	inline    bool
+	mask      maskFunc
+}
+
+var structPlans sync.Map
+
+// planFor returns the cached plan of the struct type t.
+func planFor(t reflect.Type) *structPlan {
+	if plan, ok := structPlans.Load(t); ok {
+		return plan.(*structPlan)
+	}
+	plan := buildPlan(t, map[reflect.Type]*structPlan{})
+	actual, _ := structPlans.LoadOrStore(t, plan)
+	return actual.(*structPlan)
+}
+
+func buildPlan(t reflect.Type, building map[reflect.Type]*structPlan) *structPlan {
+	if plan, ok := building[t]; ok {
+		return plan
+	}
+	plan := &structPlan{}
+	building[t] = plan
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		f := fieldPlan{index: i, name: name, omitEmpty: omitEmpty}
+		if tag, ok := field.Tag.Lookup(logTag); ok {
+			plan.walk = true
+			if tag == "-" {
+				continue
+			}
+			f.mask = parseLogTag(tag)
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && indirectType(field.Type).Kind() == reflect.Struct {
+			f.inline = true
+		}
+		if f.mask == nil && needsWalk(field.Type, building) {
+			plan.walk = true
+		}
+		plan.fields = append(plan.fields, f)
+	}
+	return plan
+}
+
+// jsonName follows encoding/json: the json tag name, or the field name.
+func jsonName(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false, true
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	name = parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// needsWalk reports whether values of t may hold log tags, interface values may hold
+// any type. It uses the cached plans when building is nil.
+func needsWalk(t reflect.Type, building map[reflect.Type]*structPlan) bool {
+	t = indirectType(t)
+	if marshals(t) {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		return true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return needsWalk(t.Elem(), building)
+	case reflect.Struct:
+		if building == nil {
+			return planFor(t).walk
+		}
+		return buildPlan(t, building).walk
+	}
+	return false
+}
+
+// marshals reports whether t encodes itself, like time.Time.
+func marshals(t reflect.Type) bool {
+	pt := reflect.PointerTo(t)
+	return t.Implements(jsonMarshalerType) || pt.Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || pt.Implements(textMarshalerType)
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// parseLogTag returns the mask of a log tag. Unknown options still mask the field.
+func parseLogTag(tag string) maskFunc {
+	parts := strings.Split(tag, ",")
+	if strings.TrimSpace(parts[0]) != "mask" {
+		return maskValue
+	}
+	rule := MaskingRule{}
+	for _, option := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		n, _ := strconv.Atoi(value)
+		switch key {
+		case "keep", "last":
+			rule.Strategy, rule.Last = MaskKeep, n
+		case "first":
+			rule.Strategy, rule.First = MaskKeep, n
+		default:
+			rule.Strategy = key
+		}
+	}
+	mask, err := rule.maskFunc("")
+	if err != nil {
+		return maskValue
+	}
+	return mask
+}
+
+// MaskedObject encodes a struct or map, or a pointer to one, honouring log tags:
+//
+//	log.Info("request", zap.Object("body", logger.MaskedObject(body)))
+func MaskedObject(v interface{}) zapcore.ObjectMarshaler {
+	return maskedValue{v: reflect.ValueOf(v)}
+}
+
+// Masked returns a field for MaskedData(data).
+func (l *Logger) Masked(key string, data interface{}) zap.Field {
+	return zap.Any(key, l.MaskedData(data))
+}
+
+// MaskedData masks structs, maps and slices while they are encoded. Fields with log
+// tags are masked by their tag, every other value by the paths, keys and rules of
+// MaskingJson, and tags are honoured inside interface values as well. Values that
+// encode themselves, such as a json.Marshaler, are masked by paths only. Anything
+// else goes through MaskingJson.
+func (l *Logger) MaskedData(data interface{}) interface{} {
+	if data == nil {
+		return nil
+	}
+	v := reflect.ValueOf(data)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if marshals(v.Type()) {
+		return l.MaskingJson(data)
+	}
+	masker := l.masker()
+	switch v.Kind() {
+	case reflect.Struct:
+		return maskedValue{v: v, masker: masker, states: masker.start()}
+	case reflect.Map:
+		if !v.IsNil() && v.Type().Key().Kind() == reflect.String {
+			return maskedValue{v: v, masker: masker, states: masker.start()}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8) {
+			break
+		}
+		return maskedArray{v: v, masker: masker, states: masker.start()}
+	}
+	return l.MaskingJson(data)
+}
+
+// maskedValue encodes a struct or map, masking it by tags and, with a masker, by the
+// rules in states.
+type maskedValue struct {
+	v      reflect.Value
+	masker *Masker
+	states []state
+}
+
+// MarshalJSON keeps the masking when the value is encoded with encoding/json.
+func (m maskedValue) MarshalJSON() ([]byte, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	if err := m.MarshalLogObject(enc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(enc.Fields)
+}
+
+func (m maskedValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	v := m.v
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		return m.encodeStruct(enc, v)
+	case reflect.Map:
+		if v.Type().Key().Kind() == reflect.String {
+			return m.encodeMap(enc, v)
+		}
+	}
+	return enc.AddReflected("value", m.plain(v, m.states))
+}
+
+func (m maskedValue) encodeStruct(enc zapcore.ObjectEncoder, v reflect.Value) error {
+	for _, f := range planFor(v.Type()).fields {
+		fv := v.Field(f.index)
+		if f.inline {
+			for fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					break
+				}
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				// embedded fields are on the same path as the struct
+				if err := m.encodeStruct(enc, fv); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if !fv.CanInterface() || (f.omitEmpty && fv.IsZero()) {
+			continue
+		}
+		if f.mask != nil {
+			addMasked(enc, f.name, f.mask(primitiveValue(fv)))
+			continue
+		}
+		if err := m.add(enc, f.name, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m maskedValue) encodeMap(enc zapcore.ObjectEncoder, v reflect.Value) error {
+	iter := v.MapRange()
+	for iter.Next() {
+		if err := m.add(enc, iter.Key().String(), iter.Value()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// add encodes the value of key, masking it when a rule matches key.
+func (m maskedValue) add(enc zapcore.ObjectEncoder, key string, v reflect.Value) error {
+	var next []state
+	if len(m.states) > 0 {
+		var rule *maskRule
+		if next, rule = m.masker.step(m.states, key, false); rule != nil {
+			addMasked(enc, key, rule.mask(primitiveValue(v)))
+			return nil
+		}
+	}
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return enc.AddReflected(key, nil)
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		if !marshals(v.Type()) {
+			enc.AddString(key, v.String())
+			return nil
+		}
+	case reflect.Bool:
+		enc.AddBool(key, v.Bool())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		enc.AddInt64(key, v.Int())
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		enc.AddUint64(key, v.Uint())
+		return nil
+	case reflect.Float32, reflect.Float64:
+		enc.AddFloat64(key, v.Float())
+		return nil
+	}
+	child := maskedValue{v: v, masker: m.masker, states: next}
+	switch {
+	case len(next) == 0 && !needsWalk(v.Type(), nil):
+		// nothing to mask below, keep the json encoding, e.g. of time.Time
+		return enc.AddReflected(key, v.Interface())
+	case marshals(v.Type()):
+	case v.Kind() == reflect.Struct:
+		return enc.AddObject(key, child)
+	case v.Kind() == reflect.Map && !v.IsNil() && v.Type().Key().Kind() == reflect.String:
+		return enc.AddObject(key, child)
+	case v.Kind() == reflect.Slice && !v.IsNil() && v.Type().Elem().Kind() != reflect.Uint8,
+		v.Kind() == reflect.Array:
+		return enc.AddArray(key, maskedArray(child))
+	}
+	return enc.AddReflected(key, m.plain(v, next))
+}
+
+// plain returns v for AddReflected, masked with the JSON walk of the rules in states.
+func (m maskedValue) plain(v reflect.Value, states []state) interface{} {
+	if len(states) == 0 {
+		return v.Interface()
+	}
+	return m.masker.maskFrom(v.Interface(), states)
+}
+
+type maskedArray maskedValue
+
+// MarshalJSON keeps the masking when the value is encoded with encoding/json.
+func (m maskedArray) MarshalJSON() ([]byte, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	if err := enc.AddArray("array", m); err != nil {
+		return nil, err
+	}
+	return json.Marshal(enc.Fields["array"])
+}
+
+func (m maskedArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for i := 0; i < m.v.Len(); i++ {
+		item := m.v.Index(i)
+		var next []state
+		if len(m.states) > 0 {
+			var rule *maskRule
+			if next, rule = m.masker.step(m.states, strconv.Itoa(i), true); rule != nil {
+				if err := enc.AppendReflected(rule.mask(primitiveValue(item))); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+			if item.IsNil() {
+				break
+			}
+			item = item.Elem()
+		}
+		child := maskedValue{v: item, masker: m.masker, states: next}
+		var err error
+		switch {
+		case item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface:
+			err = enc.AppendReflected(nil)
+		case len(next) == 0 && !needsWalk(item.Type(), nil):
+			err = enc.AppendReflected(item.Interface())
+		case marshals(item.Type()):
+			err = enc.AppendReflected(child.plain(item, next))
+		case item.Kind() == reflect.Struct,
+			item.Kind() == reflect.Map && !item.IsNil() && item.Type().Key().Kind() == reflect.String:
+			err = enc.AppendObject(child)
+		case item.Kind() == reflect.Slice && !item.IsNil() && item.Type().Elem().Kind() != reflect.Uint8,
+			item.Kind() == reflect.Array:
+			err = enc.AppendArray(maskedArray(child))
+		default:
+			err = enc.AppendReflected(child.plain(item, next))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// primitiveValue converts a value to the JSON types the mask functions expect.
+// Integers are json.Number so partial masking keeps all of their digits.
+func primitiveValue(v reflect.Value) interface{} {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Type().Implements(textMarshalerType) {
+		if text, err := v.Interface().(encoding.TextMarshaler).MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return maskedContainer
+	}
+}
+
+func addMasked(enc zapcore.ObjectEncoder, key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		enc.AddString(key, v)
+	case float64:
+		enc.AddFloat64(key, v)
+	case bool:
+		enc.AddBool(key, v)
+	default:
+		_ = enc.AddReflected(key, v)
+	}
+}
@@ -0,0 +1,180 @@
+package logger
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type taggedCard struct {
+	Number string `json:"number" log:"mask,pan"`
+	Holder string `json:"holder"`
+}
+
+type taggedBase struct {
+	TraceID string `json:"traceId"`
+}
+
+type taggedRequest struct {
+	taggedBase
+	Phone     string            `json:"phone" log:"mask,phone"`
+	Account   string            `json:"account" log:"mask,keep=4"`
+	Pin       string            `json:"pin" log:"mask"`
+	Amount    int64             `json:"amount" log:"mask"`
+	Email     *string           `json:"email,omitempty" log:"mask,email"`
+	Secret    string            `json:"secret" log:"mask,unknown"`
+	Photo     []byte            `json:"photo" log:"-"`
+	Profile   map[string]string `json:"profile" log:"mask,redact"`
+	Cards     []taggedCard      `json:"cards"`
+	Primary   *taggedCard       `json:"primary"`
+	Note      string            `json:"note,omitempty"`
+	Ignored   string            `json:"-"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Active    bool
+	private   string
+}
+
+func taggedPayload() taggedRequest {
+	email := "john.doe@example.com"
+	return taggedRequest{
+		taggedBase: taggedBase{TraceID: "t-1"},
+		Phone:      "081234567890",
+		Account:    "1234567890",
+		Pin:        "123456",
+		Amount:     1000,
+		Email:      &email,
+		Secret:     "s3cr3t",
+		Photo:      []byte("jpeg"),
+		Profile:    map[string]string{"name": "pulan"},
+		Cards:      []taggedCard{{Number: "4111111111111111", Holder: "pulan"}},
+		Primary:    &taggedCard{Number: "5500000000000004", Holder: "pulan"},
+		Ignored:    "ignored",
+		CreatedAt:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Active:     true,
+		private:    "private",
+	}
+}
+
+func TestMaskedObject(t *testing.T) {
+	enc := zapcore.NewMapObjectEncoder()
+	if err := enc.AddObject("request", MaskedObject(taggedPayload())); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := json.Marshal(enc.Fields["request"])
+	var gotMap, want map[string]interface{}
+	_ = json.Unmarshal(got, &gotMap)
+	_ = json.Unmarshal([]byte(`{
+		"traceId": "t-1",
+		"phone": "0812****7890",
+		"account": "******7890",
+		"pin": "******",
+		"amount": 0,
+		"email": "j*******@example.com",
+		"secret": "******",
+		"profile": "[REDACTED]",
+		"cards": [{"number": "411111******1111", "holder": "pulan"}],
+		"primary": {"number": "550000******0004", "holder": "pulan"},
+		"createdAt": "2024-01-02T03:04:05Z",
+		"Active": true
+	}`), &want)
+	if !reflect.DeepEqual(gotMap, want) {
+		t.Errorf("MaskedObject() = %s", got)
+	}
+}
+
+func TestLogger_MaskedData(t *testing.T) {
+	l := &Logger{Options: Options{MaskingLogJsonPath: "pin|password|cards.#.holder"}}
+	tagged := taggedCard{Number: "4111111111111111", Holder: "pulan"}
+	tests := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{"nil", nil, `null`},
+		{"tagged struct", taggedCard{Number: "4111111111111111", Holder: "pulan"}, `{"holder":"pulan","number":"411111******1111"}`},
+		{"pointer to tagged struct", &taggedCard{Number: "4111111111111111"}, `{"holder":"","number":"411111******1111"}`},
+		{"untagged struct uses paths", struct {
+			Pin string `json:"pin"`
+		}{"123456"}, `{"pin":"******"}`},
+		{"map uses paths", map[string]interface{}{"pin": "123456"}, `{"pin":"******"}`},
+		{"tagged struct uses paths", struct {
+			Password string `json:"password"`
+			Pin      string `json:"pin" log:"mask,keep=2"`
+			Name     string `json:"name"`
+		}{"s3cr3t", "123456", "pulan"}, `{"name":"pulan","password":"******","pin":"****56"}`},
+		{"tagged struct in a map", map[string]interface{}{"data": tagged}, `{"data":{"holder":"pulan","number":"411111******1111"}}`},
+		{"tagged struct in an interface field", struct {
+			Data interface{} `json:"data"`
+		}{&tagged}, `{"data":{"holder":"pulan","number":"411111******1111"}}`},
+		{"slice of tagged structs uses paths", map[string]interface{}{"cards": []interface{}{tagged}},
+			`{"cards":[{"holder":"******","number":"411111******1111"}]}`},
+		{"slice", []taggedCard{tagged}, `[{"holder":"pulan","number":"411111******1111"}]`},
+		{"string", "pin=123456", `"pin=123456"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(l.MaskedData(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MaskedData() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLogTag(t *testing.T) {
+	tests := []struct {
+		tag   string
+		value interface{}
+		want  interface{}
+	}{
+		{"mask", "secret", "******"},
+		{"mask", float64(12), float64(0)},
+		{"mask,keep=4", "1234567890", "******7890"},
+		{"mask,first=2,last=2", "1234567890", "12******90"},
+		{"mask,email", "john@example.com", "j***@example.com"},
+		{"mask,nik", "3201011234567890", "320101**********"},
+		{"mask,redact", "secret", "[REDACTED]"},
+		{"mask,unknown", "secret", "******"},
+		{"anything", "secret", "******"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := parseLogTag(tt.tag)(tt.value); got != tt.want {
+				t.Errorf("parseLogTag(%q)(%v) = %v, want %v", tt.tag, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func benchmarkEncode(b *testing.B, field func() zap.Field) {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	ent := zapcore.Entry{Time: time.Now()}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, err := enc.EncodeEntry(ent, []zap.Field{field()})
+		if err != nil {
+			b.Fatal(err)
+		}
+		buf.Free()
+	}
+}
+
+func BenchmarkMaskingJson(b *testing.B) {
+	l := &Logger{Options: Options{MaskingLogJsonPath: "phone|account|pin|amount|email|secret|photo|profile|cards.#.number|primary.number"}}
+	payload := taggedPayload()
+	benchmarkEncode(b, func() zap.Field { return zap.Any("request", l.MaskingJson(payload)) })
+}
+
+func BenchmarkMasked(b *testing.B) {
+	l := &Logger{}
+	payload := taggedPayload()
+	benchmarkEncode(b, func() zap.Field { return l.Masked("request", payload) })
+}
@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func maskingPayload() map[string]interface{} {
+	return map[string]interface{}{
+		"PIN":    "123456",
+		"amount": 1000,
+		"active": true,
+		"note":   nil,
+		"empty":  "",
+		"data": map[string]interface{}{
+			"token":   "abc",
+			"profile": map[string]interface{}{"Password": "secret", "name": "pulan"},
+		},
+		"items": []interface{}{
+			map[string]interface{}{"cardNumber": "4111111111111111", "qty": 1},
+			map[string]interface{}{"cardNumber": "5500000000000004", "qty": 2},
+		},
+	}
+}
+
+func TestMasker_Mask(t *testing.T) {
+	tests := []struct {
+		name   string
+		option MaskingOption
+		want   map[string]interface{}
+	}{
+		{
+			"exact path, case insensitive",
+			MaskingOption{Paths: []string{"pin", "data.TOKEN"}},
+			map[string]interface{}{
+				"PIN": "******", "amount": float64(1000), "active": true, "note": nil, "empty": "",
+				"data": map[string]interface{}{
+					"token":   "******",
+					"profile": map[string]interface{}{"Password": "secret", "name": "pulan"},
+				},
+				"items": []interface{}{
+					map[string]interface{}{"cardNumber": "4111111111111111", "qty": float64(1)},
+					map[string]interface{}{"cardNumber": "5500000000000004", "qty": float64(2)},
+				},
+			},
+		},
+		{
+			"array items, any depth and types",
+			MaskingOption{Paths: []string{"items.#.cardNumber", "**.password", "amount", "active", "note", "empty", "data.profile"}},
+			map[string]interface{}{
+				"PIN": "123456", "amount": float64(0), "active": true, "note": nil, "empty": "",
+				"data": map[string]interface{}{
+					"token":   "abc",
+					"profile": "***Mask JSON***",
+				},
+				"items": []interface{}{
+					map[string]interface{}{"cardNumber": "******", "qty": float64(1)},
+					map[string]interface{}{"cardNumber": "******", "qty": float64(2)},
+				},
+			},
+		},
+		{
+			"single wildcard and index",
+			MaskingOption{Paths: []string{"data.*.name", "items.1.qty", "*.token"}},
+			map[string]interface{}{
+				"PIN": "123456", "amount": float64(1000), "active": true, "note": nil, "empty": "",
+				"data": map[string]interface{}{
+					"token":   "******",
+					"profile": map[string]interface{}{"Password": "secret", "name": "******"},
+				},
+				"items": []interface{}{
+					map[string]interface{}{"cardNumber": "4111111111111111", "qty": float64(1)},
+					map[string]interface{}{"cardNumber": "5500000000000004", "qty": float64(0)},
+				},
+			},
+		},
+		{
+			"keys at any depth",
+			MaskingOption{Keys: []string{"cardnumber", "password", "pin"}},
+			map[string]interface{}{
+				"PIN": "******", "amount": float64(1000), "active": true, "note": nil, "empty": "",
+				"data": map[string]interface{}{
+					"token":   "abc",
+					"profile": map[string]interface{}{"Password": "******", "name": "pulan"},
+				},
+				"items": []interface{}{
+					map[string]interface{}{"cardNumber": "******", "qty": float64(1)},
+					map[string]interface{}{"cardNumber": "******", "qty": float64(2)},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := maskingPayload()
+			masker, _ := NewMasker(tt.option)
+			if got := masker.Mask(data); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mask() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(data, maskingPayload()) {
+				t.Errorf("Mask() changed its input: %v", data)
+			}
+		})
+	}
+}
+
+func TestLogger_MaskingOption(t *testing.T) {
+	l := New(Options{
+		Stdout:             true,
+		MaskingLogJsonPath: "pin",
+		Masking:            MaskingOption{Paths: []string{"items.#.cardNumber"}, Keys: []string{"password"}},
+	})
+	got := l.MaskingJson(maskingPayload()).(map[string]interface{})
+	if got["PIN"] != "******" {
+		t.Errorf("PIN = %v, want masked", got["PIN"])
+	}
+	if item := got["items"].([]interface{})[0].(map[string]interface{}); item["cardNumber"] != "******" {
+		t.Errorf("cardNumber = %v, want masked", item["cardNumber"])
+	}
+	profile := got["data"].(map[string]interface{})["profile"].(map[string]interface{})
+	if profile["Password"] != "******" {
+		t.Errorf("Password = %v, want masked", profile["Password"])
+	}
+}
+
+func TestMaskerFor_Bounded(t *testing.T) {
+	for i := 0; i < maxCachedMaskers*2; i++ {
+		paths := "pin|field" + strconv.Itoa(i)
+		got := maskerFor(paths).Mask(map[string]interface{}{"pin": "123456"})
+		if want := map[string]interface{}{"pin": "******"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("Mask() = %v, want %v", got, want)
+		}
+	}
+	var cached int
+	maskers.Range(func(key, value interface{}) bool {
+		cached++
+		return true
+	})
+	if cached > maxCachedMaskers {
+		t.Errorf("cached maskers = %v, want at most %v", cached, maxCachedMaskers)
+	}
+}
@@ -1,6 +1,9 @@
 package logger
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 type Options struct {
 	FileLocation       string        `json:"fileLocation"`
@@ -10,12 +13,133 @@ type Options struct {
 	Stdout             bool          `json:"stdout"`
 	MaskingLogJsonPath string        `json:"maskingLogJsonPath"`
 	PublishLog         bool          `json:"publishLog"`
+	Publish            PublishOption `json:"publish"`
+	// FileMaxSize rotates the file once it reaches this size in megabytes, 0 rotates daily only.
+	FileMaxSize int64 `json:"fileMaxSize"`
+	// FileMaxBackups is the number of rotated files kept, 0 keeps every file younger than FileMaxAge.
+	FileMaxBackups int `json:"fileMaxBackups"`
+	// FileCompress gzips rotated files.
+	FileCompress bool `json:"fileCompress"`
+	// FileLinkName is a symlink that always points to the current file, e.g. logs/service.log.
+	FileLinkName string `json:"fileLinkName"`
+	// Level is the minimum level written: debug, info, warn, error or fatal. Defaults to info,
+	// NewE fails on other values.
+	Level string `json:"level"`
+	// Encoding is console (default), json or logfmt.
+	Encoding    string      `json:"encoding"`
+	EncoderKeys EncoderKeys `json:"encoderKeys"`
+	// TimeZone is an IANA name, UTC or Local. Defaults to Asia/Jakarta.
+	TimeZone string `json:"timeZone"`
+	// TimeFormat is a Go layout, RFC3339, RFC3339Nano or ISO8601. Defaults to 2006-01-02 15:04:05.999.
+	TimeFormat string `json:"timeFormat"`
+	// Masking adds wildcard paths and key names to MaskingLogJsonPath.
+	Masking MaskingOption `json:"masking"`
+	// Sampling and RateLimit suppress repeated entries, see DroppedStats. nil disables them.
+	Sampling  *SamplingOption  `json:"sampling"`
+	RateLimit *RateLimitOption `json:"rateLimit"`
+	// Headers selects the headers written by request logs, DefaultMaskedHeaders are always masked.
+	Headers HeaderOption `json:"headers"`
+	// Scrub removes secrets such as bearer tokens and card numbers from messages and
+	// fields, TDR records are not scrubbed. nil disables it.
+	Scrub *ScrubOption `json:"scrub"`
+	// Sinks writes to several outputs at once. When empty, Stdout chooses between
+	// stdout and the rotating file.
+	Sinks []SinkOption `json:"sinks"`
 }
 
+// SinkOption declares one output of the Logger.
+type SinkOption struct {
+	// Type is stdout, stderr, file, syslog or writer.
+	Type string `json:"type"`
+	// Level is the minimum level of this sink. Options.Level still applies first.
+	Level string `json:"level"`
+	// Encoding overrides Options.Encoding for this sink.
+	Encoding string `json:"encoding"`
+
+	// file, empty values fall back to Options
+	FileLocation string        `json:"fileLocation"`
+	FileName     string        `json:"fileName"`
+	FileMaxAge   time.Duration `json:"fileMaxAge"`
+	FileLinkName string        `json:"fileLinkName"`
+
+	// syslog, an empty Network writes to the local syslog daemon
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Tag     string `json:"tag"`
+
+	// writer
+	Writer io.Writer `json:"-"`
+}
+
+const (
+	SinkStdout = "stdout"
+	SinkStderr = "stderr"
+	SinkFile   = "file"
+	SinkSyslog = "syslog"
+	SinkWriter = "writer"
+)
+
+// SamplingOption keeps the first Initial entries with the same level, caller and message
+// every Tick, then only every Thereafter-th entry. Thereafter 0 drops the rest.
+type SamplingOption struct {
+	Initial    int           `json:"initial"` // default 100
+	Thereafter int           `json:"thereafter"`
+	Tick       time.Duration `json:"tick"` // in milliseconds, default 1000
+}
+
+// RateLimitOption writes at most Limit entries with the same level, caller and message every Interval.
+type RateLimitOption struct {
+	Limit    int           `json:"limit"`
+	Interval time.Duration `json:"interval"` // in milliseconds, default 1000
+}
+
+// EncoderKeys renames the standard keys of each log line. Empty keys use the
+// defaults time, level and message; use "-" to leave a key out. The caller is
+// only written when CallerKey is set.
+type EncoderKeys struct {
+	TimeKey    string `json:"timeKey"`
+	LevelKey   string `json:"levelKey"`
+	MessageKey string `json:"messageKey"`
+	CallerKey  string `json:"callerKey"`
+}
+
+const (
+	EncodingConsole = "console"
+	EncodingJSON    = "json"
+	EncodingLogfmt  = "logfmt"
+)
+
+// PublishOption ships log entries to a remote collector when Options.PublishLog is true.
 type PublishOption struct {
 	InstId       string        `json:"instId"`
-	PublishLogTo string        `json:"publishLogTo"`
-	Timeout      time.Duration `json:"timeout"`
+	PublishLogTo string        `json:"publishLogTo"` // url of the collector
+	Timeout      time.Duration `json:"timeout"`      // in seconds
 	DebugMode    bool          `json:"debugMode"`
 	SkipTLS      bool          `json:"skipTLS"`
+	// Kind is http (default), a JSON array per batch, or kafka, a Kafka REST proxy
+	// request such as http://proxy:8082/topics/logs.
+	Kind string `json:"kind"`
+	// Stream is system (default), tdr or all.
+	Stream string `json:"stream"`
+	// BatchSize is the maximum number of entries per request, default 100.
+	BatchSize int `json:"batchSize"`
+	// BufferSize is the number of entries kept in memory, default 1000.
+	// Entries are dropped while the buffer is full.
+	BufferSize    int           `json:"bufferSize"`
+	FlushInterval time.Duration `json:"flushInterval"` // in milliseconds, default 1000
+	// MaxRetry is the number of retries of a failed batch, default 3, -1 disables retries.
+	MaxRetry     int           `json:"maxRetry"`
+	RetryBackoff time.Duration `json:"retryBackoff"` // in milliseconds, doubled every retry, default 100
+	// OnError is called with every batch that could not be published. Without it,
+	// DebugMode reports the failures on stderr, the zap ErrorOutput.
+	OnError func(err error) `json:"-"`
 }
+
+const (
+	PublishKindHTTP  = "http"
+	PublishKindKafka = "kafka"
+
+	PublishStreamSystem = "system"
+	PublishStreamTdr    = "tdr"
+	PublishStreamAll    = "all"
+)
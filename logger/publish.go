@@ -0,0 +1,323 @@
+package logger
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultPublishBatchSize     = 100
+	defaultPublishBufferSize    = 1000
+	defaultPublishFlushInterval = time.Second
+	defaultPublishTimeout       = 10 * time.Second
+	defaultPublishMaxRetry      = 3
+	defaultPublishRetryBackoff  = 100 * time.Millisecond
+)
+
+// PublishStats counts the entries handled by the publisher.
+type PublishStats struct {
+	Published uint64 `json:"published"`
+	// Dropped entries did not fit in the buffer.
+	Dropped uint64 `json:"dropped"`
+	// Failed entries were still rejected after MaxRetry retries.
+	Failed uint64 `json:"failed"`
+}
+
+// publisher is a zapcore.WriteSyncer that buffers encoded entries and sends them
+// in batches from its own goroutine, so logging never waits for the network.
+type publisher struct {
+	options       PublishOption
+	client        *http.Client
+	flushInterval time.Duration
+	retryBackoff  time.Duration
+
+	entries chan []byte
+	flush   chan chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+	close   sync.Once
+	// errorOutput receives failures in DebugMode when OnError is not set.
+	errorOutput zapcore.WriteSyncer
+
+	published atomic.Uint64
+	dropped   atomic.Uint64
+	failed    atomic.Uint64
+}
+
+func newPublisher(options PublishOption) (*publisher, error) {
+	if options.PublishLogTo == "" {
+		return nil, fmt.Errorf("logger: publish: publishLogTo is empty")
+	}
+	switch strings.ToLower(options.Kind) {
+	case "", PublishKindHTTP, PublishKindKafka:
+	default:
+		return nil, fmt.Errorf("logger: publish: unknown kind %s", options.Kind)
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaultPublishBatchSize
+	}
+	if options.BufferSize <= 0 {
+		options.BufferSize = defaultPublishBufferSize
+	}
+	if options.MaxRetry == 0 {
+		options.MaxRetry = defaultPublishMaxRetry
+	}
+
+	timeout := options.Timeout * time.Second
+	if timeout <= 0 {
+		timeout = defaultPublishTimeout
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if options.SkipTLS {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	p := &publisher{
+		options:       options,
+		client:        &http.Client{Timeout: timeout, Transport: transport},
+		flushInterval: options.FlushInterval * time.Millisecond,
+		retryBackoff:  options.RetryBackoff * time.Millisecond,
+		entries:       make(chan []byte, options.BufferSize),
+		flush:         make(chan chan struct{}),
+		done:          make(chan struct{}),
+		stopped:       make(chan struct{}),
+		errorOutput:   zapcore.Lock(os.Stderr),
+	}
+	if p.flushInterval <= 0 {
+		p.flushInterval = defaultPublishFlushInterval
+	}
+	if p.retryBackoff <= 0 {
+		p.retryBackoff = defaultPublishRetryBackoff
+	}
+	go p.run()
+	return p, nil
+}
+
+// Write queues one encoded entry, or drops it when the buffer is full.
+func (p *publisher) Write(entry []byte) (int, error) {
+	select {
+	case <-p.done:
+		p.dropped.Add(1)
+		return len(entry), nil
+	default:
+	}
+	// zap reuses the buffer after Write returns
+	record := bytes.TrimRight(entry, "\r\n")
+	record = append(make([]byte, 0, len(record)), record...)
+	select {
+	case p.entries <- record:
+	default:
+		p.dropped.Add(1)
+	}
+	return len(entry), nil
+}
+
+// Sync sends everything queued so far and waits for it.
+func (p *publisher) Sync() error {
+	flushed := make(chan struct{})
+	select {
+	case p.flush <- flushed:
+		<-flushed
+	case <-p.stopped:
+	}
+	return nil
+}
+
+// Close sends the remaining entries and stops the publisher.
+func (p *publisher) Close() error {
+	p.close.Do(func() {
+		close(p.done)
+		<-p.stopped
+	})
+	return nil
+}
+
+func (p *publisher) Stats() PublishStats {
+	return PublishStats{
+		Published: p.published.Load(),
+		Dropped:   p.dropped.Load(),
+		Failed:    p.failed.Load(),
+	}
+}
+
+func (p *publisher) run() {
+	defer close(p.stopped)
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([][]byte, 0, p.options.BatchSize)
+	send := func() {
+		if len(batch) > 0 {
+			p.send(batch)
+			batch = make([][]byte, 0, p.options.BatchSize)
+		}
+	}
+	// drain moves the queued entries into batches without waiting for new ones
+	drain := func() {
+		for {
+			select {
+			case record := <-p.entries:
+				batch = append(batch, record)
+				if len(batch) >= p.options.BatchSize {
+					send()
+				}
+			default:
+				send()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case record := <-p.entries:
+			batch = append(batch, record)
+			if len(batch) >= p.options.BatchSize {
+				send()
+			}
+		case <-ticker.C:
+			send()
+		case flushed := <-p.flush:
+			drain()
+			close(flushed)
+		case <-p.done:
+			drain()
+			return
+		}
+	}
+}
+
+// send posts batch, retrying transport errors, 429 and 5xx responses with backoff.
+func (p *publisher) send(batch [][]byte) {
+	body, contentType := p.encode(batch)
+	backoff := p.retryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := p.post(body, contentType)
+		if err == nil {
+			p.published.Add(uint64(len(batch)))
+			return
+		}
+		if !retry || attempt >= p.options.MaxRetry {
+			p.failed.Add(uint64(len(batch)))
+			p.reportError(fmt.Errorf("logger: publish %d entries: %w", len(batch), err))
+			return
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (p *publisher) reportError(err error) {
+	switch {
+	case p.options.OnError != nil:
+		p.options.OnError(err)
+	case p.options.DebugMode:
+		fmt.Fprintf(p.errorOutput, "%v %v\n", time.Now(), err)
+		_ = p.errorOutput.Sync()
+	}
+}
+
+func (p *publisher) post(body []byte, contentType string) (retry bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, p.options.PublishLogTo, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// encode builds the request body: a JSON array for http, a Kafka REST v2 records payload for kafka.
+func (p *publisher) encode(batch [][]byte) ([]byte, string) {
+	var buf bytes.Buffer
+	kafka := strings.ToLower(p.options.Kind) == PublishKindKafka
+	if kafka {
+		buf.WriteString(`{"records":[`)
+	} else {
+		buf.WriteByte('[')
+	}
+	for i, record := range batch {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if kafka {
+			buf.WriteString(`{"value":`)
+			buf.Write(record)
+			buf.WriteByte('}')
+		} else {
+			buf.Write(record)
+		}
+	}
+	if kafka {
+		buf.WriteString(`]}`)
+		return buf.Bytes(), "application/vnd.kafka.json.v2+json"
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), "application/json"
+}
+
+// core writes entries enabled by level to the publisher, tagged with InstId when set.
+func (p *publisher) core(encoder zapcore.Encoder, level zapcore.LevelEnabler) zapcore.Core {
+	core := zapcore.NewCore(encoder, p, level)
+	if p.options.InstId != "" {
+		core = core.With([]zapcore.Field{zap.String("instId", p.options.InstId)})
+	}
+	return core
+}
+
+// publishEncoderOptions always encodes published entries as JSON.
+func publishEncoderOptions(config Options) Options {
+	config.Encoding = EncodingJSON
+	return config
+}
+
+// Sync flushes buffered entries, including those waiting to be published.
+func (l *Logger) Sync() error {
+	var errs []error
+	if l.loggerSys != nil {
+		errs = append(errs, l.loggerSys.Sync())
+	}
+	if l.loggerTdr != nil {
+		errs = append(errs, l.loggerTdr.Sync())
+	}
+	return errors.Join(errs...)
+}
+
+// Close flushes the Logger and stops publishing. Entries logged afterwards are
+// still written locally but no longer published.
+func (l *Logger) Close() error {
+	err := l.Sync()
+	if l.publisher != nil {
+		_ = l.publisher.Close()
+	}
+	return err
+}
+
+// PublishStats returns the publish counters, all zero when publishing is disabled.
+func (l *Logger) PublishStats() PublishStats {
+	if l.publisher == nil {
+		return PublishStats{}
+	}
+	return l.publisher.Stats()
+}
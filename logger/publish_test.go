@@ -0,0 +1,267 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// collector records the entries posted to it, answering with the statuses in order
+// and 200 once they are used up.
+type collector struct {
+	mu       sync.Mutex
+	statuses []int
+	requests int
+	types    []string
+	bodies   [][]byte
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	c.types = append(c.types, r.Header.Get("Content-Type"))
+	if len(c.statuses) > 0 {
+		status := c.statuses[0]
+		c.statuses = c.statuses[1:]
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+	}
+	c.bodies = append(c.bodies, body)
+}
+
+// entries decodes the accepted http batches.
+func (c *collector) entries(t *testing.T) []map[string]interface{} {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var all []map[string]interface{}
+	for _, body := range c.bodies {
+		var batch []map[string]interface{}
+		if err := json.Unmarshal(body, &batch); err != nil {
+			t.Fatalf("unmarshal batch: %v\n%s", err, body)
+		}
+		all = append(all, batch...)
+	}
+	return all
+}
+
+func TestLogger_Publish(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	var local bytes.Buffer
+	l := New(Options{
+		Sinks:      []SinkOption{{Type: SinkWriter, Writer: &local}},
+		PublishLog: true,
+		Publish:    PublishOption{InstId: "wallet", PublishLogTo: server.URL, BatchSize: 2},
+	})
+	for i := 0; i < 5; i++ {
+		l.Info("published", zap.Int("i", i))
+	}
+	if err := l.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	entries := c.entries(t)
+	if len(entries) != 5 {
+		t.Fatalf("published entries = %v, want 5", len(entries))
+	}
+	for i, entry := range entries {
+		if entry["message"] != "published" || entry["instId"] != "wallet" || entry["i"] != float64(i) {
+			t.Errorf("entry %d = %v", i, entry)
+		}
+	}
+	if c.requests != 3 {
+		t.Errorf("requests = %v, want 3 batches of at most 2", c.requests)
+	}
+	if c.types[0] != "application/json" {
+		t.Errorf("Content-Type = %v, want application/json", c.types[0])
+	}
+	if got, want := l.PublishStats(), (PublishStats{Published: 5}); got != want {
+		t.Errorf("PublishStats() = %+v, want %+v", got, want)
+	}
+	if bytes.Count(local.Bytes(), []byte("\n")) != 5 {
+		t.Errorf("local sink = %s, want 5 lines", local.String())
+	}
+}
+
+func TestLogger_PublishKafka(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	l := New(Options{
+		Sinks:      []SinkOption{{Type: SinkWriter, Writer: io.Discard}},
+		PublishLog: true,
+		Publish:    PublishOption{PublishLogTo: server.URL + "/topics/logs", Kind: PublishKindKafka},
+	})
+	l.Info("to kafka")
+	_ = l.Close()
+
+	if len(c.bodies) != 1 {
+		t.Fatalf("requests = %v, want 1", len(c.bodies))
+	}
+	var payload struct {
+		Records []struct {
+			Value map[string]interface{} `json:"value"`
+		} `json:"records"`
+	}
+	if err := json.Unmarshal(c.bodies[0], &payload); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, c.bodies[0])
+	}
+	if len(payload.Records) != 1 || payload.Records[0].Value["message"] != "to kafka" {
+		t.Errorf("payload = %s", c.bodies[0])
+	}
+	if c.types[0] != "application/vnd.kafka.json.v2+json" {
+		t.Errorf("Content-Type = %v", c.types[0])
+	}
+}
+
+func TestLogger_PublishRetry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		maxRetry     int
+		wantRequests int
+		want         PublishStats
+	}{
+		{"retry until accepted", []int{503, 429, 200}, 3, 3, PublishStats{Published: 1}},
+		{"give up after max retry", []int{500, 500, 500}, 2, 3, PublishStats{Failed: 1}},
+		{"client error is not retried", []int{400}, 3, 1, PublishStats{Failed: 1}},
+		{"retry disabled", []int{503}, -1, 1, PublishStats{Failed: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &collector{statuses: tt.statuses}
+			server := httptest.NewServer(c)
+			defer server.Close()
+
+			l := New(Options{
+				Sinks:      []SinkOption{{Type: SinkWriter, Writer: io.Discard}},
+				PublishLog: true,
+				Publish:    PublishOption{PublishLogTo: server.URL, MaxRetry: tt.maxRetry, RetryBackoff: 1},
+			})
+			l.Info("retry")
+			_ = l.Close()
+
+			if c.requests != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", c.requests, tt.wantRequests)
+			}
+			if got := l.PublishStats(); got != tt.want {
+				t.Errorf("PublishStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogger_PublishError(t *testing.T) {
+	c := &collector{statuses: []int{500}}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	var reported []error
+	l := New(Options{
+		Sinks:      []SinkOption{{Type: SinkWriter, Writer: io.Discard}},
+		PublishLog: true,
+		Publish: PublishOption{PublishLogTo: server.URL, MaxRetry: -1, DebugMode: true,
+			OnError: func(err error) { reported = append(reported, err) }},
+	})
+	l.Info("lost")
+	_ = l.Close()
+	if len(reported) != 1 || !strings.Contains(reported[0].Error(), "500") {
+		t.Errorf("OnError() got %v, want the 500 response", reported)
+	}
+
+	// without OnError, DebugMode writes to the error output instead of stdout
+	failing := httptest.NewServer(&collector{statuses: []int{500}})
+	defer failing.Close()
+	var errorOutput bytes.Buffer
+	l = New(Options{
+		Sinks:      []SinkOption{{Type: SinkWriter, Writer: io.Discard}},
+		PublishLog: true,
+		Publish:    PublishOption{PublishLogTo: failing.URL, MaxRetry: -1, DebugMode: true},
+	})
+	l.publisher.errorOutput = zapcore.AddSync(&errorOutput)
+	l.Info("lost")
+	_ = l.Close()
+	if !strings.Contains(errorOutput.String(), "logger: publish 1 entries") {
+		t.Errorf("error output = %q, want the failure", errorOutput.String())
+	}
+}
+
+func TestPublisher_Drop(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	p, err := newPublisher(PublishOption{PublishLogTo: server.URL, BatchSize: 1, BufferSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const written = 20
+	for i := 0; i < written; i++ {
+		_, _ = p.Write([]byte(`{"i":1}` + "\n"))
+	}
+	close(release)
+	_ = p.Close()
+
+	stats := p.Stats()
+	if stats.Dropped < written-2 {
+		t.Errorf("Dropped = %v, want at least %v", stats.Dropped, written-2)
+	}
+	if stats.Published+stats.Dropped != written {
+		t.Errorf("stats = %+v, want published and dropped to add up to %v", stats, written)
+	}
+}
+
+func TestLogger_PublishTdr(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c)
+	defer server.Close()
+
+	l := New(Options{
+		Sinks:      []SinkOption{{Type: SinkWriter, Writer: io.Discard}},
+		PublishLog: true,
+		Publish:    PublishOption{PublishLogTo: server.URL, Stream: PublishStreamTdr},
+	})
+	l.Info("system entry is not published")
+	l.Tdr(LogTdrModel{AppName: "wallet", Path: "/v1/balance"})
+	_ = l.Close()
+
+	entries := c.entries(t)
+	if len(entries) != 1 || entries[0]["app"] != "wallet" || entries[0]["path"] != "/v1/balance" {
+		t.Errorf("published entries = %v, want the tdr record only", entries)
+	}
+}
+
+func TestNewPublisher_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		options PublishOption
+	}{
+		{"no url", PublishOption{}},
+		{"unknown kind", PublishOption{PublishLogTo: "http://localhost", Kind: "amqp"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newPublisher(tt.options); err == nil {
+				t.Errorf("newPublisher() error = nil, want error")
+			}
+		})
+	}
+}
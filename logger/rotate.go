@@ -0,0 +1,170 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	RotateLogs "github.com/lestrrat-go/file-rotatelogs"
+	"go.uber.org/zap/zapcore"
+)
+
+// megabyte is the unit of FileMaxSize, a variable so tests can rotate small files.
+var megabyte int64 = 1024 * 1024
+
+// getRotateWriter writes to FileLocation/<date>.FileName, starting a new file every
+// day and, with FileMaxSize, whenever the file grows too large (<date>.FileName.1, .2, ...).
+func getRotateWriter(config Options) (zapcore.WriteSyncer, error) {
+	if config.FileName == "" {
+		config.FileName = "log"
+	}
+	maxAge := config.FileMaxAge * 24 * time.Hour
+	if maxAge <= 0 {
+		// the rotatelogs default
+		maxAge = 7 * 24 * time.Hour
+	}
+	options := []RotateLogs.Option{
+		RotateLogs.WithMaxAge(maxAge),
+		RotateLogs.WithRotationTime(time.Hour),
+	}
+	if config.FileMaxSize > 0 {
+		options = append(options, RotateLogs.WithRotationSize(config.FileMaxSize*megabyte))
+	}
+	if config.FileLinkName != "" {
+		options = append(options, RotateLogs.WithLinkName(config.FileLinkName))
+	}
+	var handler *rotateHandler
+	if config.FileCompress || config.FileMaxBackups > 0 || config.FileMaxSize > 0 {
+		// only this writer's files, FileName "log" must not match error.log or a TDR file
+		_, prefix := filepath.Split(config.FileLocation)
+		handler = &rotateHandler{
+			pattern:    config.FileLocation + "*." + config.FileName + "*",
+			name:       regexp.MustCompile(`^` + regexp.QuoteMeta(prefix) + `\d{4}-\d{2}-\d{2}\.` + regexp.QuoteMeta(config.FileName) + `(\.\d+)?(\.gz)?$`),
+			compress:   config.FileCompress,
+			maxBackups: config.FileMaxBackups,
+			maxAge:     maxAge,
+		}
+		options = append(options, RotateLogs.WithHandler(handler))
+	}
+	rotate, err := RotateLogs.New(config.FileLocation+"%Y-%m-%d."+config.FileName, options...)
+	if err != nil {
+		return nil, err
+	}
+	if handler != nil {
+		handler.rotate = rotate
+	}
+	return zapcore.AddSync(rotate), nil
+}
+
+// rotateHandler compresses the previous file and removes old backups after every rotation.
+// rotatelogs purges files by age but does not see the .1 and .gz names, so the handler
+// applies FileMaxAge to them as well.
+type rotateHandler struct {
+	pattern    string
+	name       *regexp.Regexp
+	compress   bool
+	maxBackups int
+	maxAge     time.Duration
+	rotate     *RotateLogs.RotateLogs
+	mu         sync.Mutex
+}
+
+func (h *rotateHandler) Handle(e RotateLogs.Event) {
+	rotated, ok := e.(*RotateLogs.FileRotatedEvent)
+	if !ok {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.compress && rotated.PreviousFile() != "" {
+		_ = compressFile(rotated.PreviousFile())
+	}
+	h.removeBackups()
+}
+
+// removeBackups removes files older than maxAge and keeps the newest maxBackups files
+// next to the current one.
+func (h *rotateHandler) removeBackups() {
+	// events are handled asynchronously, so the event may already be stale
+	current := filepath.Clean(h.rotate.CurrentFileName())
+	matches, err := filepath.Glob(h.pattern)
+	if err != nil {
+		return
+	}
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	var backups []backup
+	cutoff := time.Now().Add(-h.maxAge)
+	for _, path := range matches {
+		if path == current || !h.name.MatchString(filepath.Base(path)) {
+			continue
+		}
+		fi, err := os.Lstat(path)
+		if err != nil || fi.Mode()&os.ModeSymlink != 0 || fi.IsDir() {
+			continue
+		}
+		if h.maxAge > 0 && fi.ModTime().Before(cutoff) {
+			_ = os.Remove(path)
+			continue
+		}
+		backups = append(backups, backup{path: path, modTime: fi.ModTime()})
+	}
+	if h.maxBackups <= 0 || len(backups) <= h.maxBackups {
+		return
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].modTime.Equal(backups[j].modTime) {
+			return backups[i].path > backups[j].path
+		}
+		return backups[i].modTime.After(backups[j].modTime)
+	})
+	for _, b := range backups[h.maxBackups:] {
+		_ = os.Remove(b.path)
+	}
+}
+
+// compressFile replaces path with path.gz.
+func compressFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path + ".gz")
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	// keep the modification time so retention still orders the backups correctly
+	if fi, statErr := src.Stat(); statErr == nil {
+		_ = os.Chtimes(path+".gz", fi.ModTime(), fi.ModTime())
+	}
+	_ = src.Close()
+	return os.Remove(path)
+}
@@ -0,0 +1,208 @@
+package logger
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// eventually retries check until it passes, rotation handlers run in their own goroutine.
+func eventually(t *testing.T, check func() string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		problem := check()
+		if problem == "" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(problem)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLogger_RotateBySize(t *testing.T) {
+	defer func(unit int64) { megabyte = unit }(megabyte)
+	megabyte = 1
+
+	dir := t.TempDir()
+	link := filepath.Join(dir, "service.log")
+	l, err := NewE(Options{
+		FileLocation:   dir + "/",
+		FileName:       "service.log",
+		FileMaxAge:     1,
+		FileMaxSize:    200,
+		FileMaxBackups: 2,
+		FileCompress:   true,
+		FileLinkName:   link,
+		Encoding:       EncodingJSON,
+	})
+	if err != nil {
+		t.Fatalf("NewE() error = %v", err)
+	}
+	for i := 0; i < 30; i++ {
+		l.Info("a line long enough to fill the file quickly")
+	}
+
+	pattern := filepath.Join(dir, "*.service.log*")
+	eventually(t, func() string {
+		matches, _ := filepath.Glob(pattern)
+		var plain, compressed int
+		for _, path := range matches {
+			if strings.HasSuffix(path, ".gz") {
+				compressed++
+			} else {
+				plain++
+			}
+		}
+		// the current file plus two compressed backups
+		if plain != 1 || compressed != 2 {
+			return "files = " + strings.Join(matches, ", ")
+		}
+		return ""
+	})
+
+	current, err := os.Readlink(link)
+	if err != nil {
+		t.Fatalf("Readlink() error = %v", err)
+	}
+	if strings.HasSuffix(current, ".gz") || !strings.Contains(current, ".service.log") {
+		t.Errorf("link points to %v, want the current file", current)
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(dir, "*.gz"))
+	f, err := os.Open(backups[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	if b, _ := io.ReadAll(gz); !strings.Contains(string(b), "a line long enough") {
+		t.Errorf("backup = %s, want log lines", b)
+	}
+}
+
+func TestLogger_RotateMaxAgeCompressed(t *testing.T) {
+	defer func(unit int64) { megabyte = unit }(megabyte)
+	megabyte = 1
+
+	dir := t.TempDir()
+	// backups rotatelogs does not purge by itself
+	old := time.Now().Add(-72 * time.Hour)
+	for _, name := range []string{"2020-01-01.service.log.gz", "2020-01-01.service.log.1.gz", "2020-01-01.service.log.2"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("old"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_ = os.Chtimes(path, old, old)
+	}
+	l, err := NewE(Options{
+		FileLocation: dir + "/",
+		FileName:     "service.log",
+		FileMaxAge:   1,
+		FileMaxSize:  200,
+		FileCompress: true,
+		Encoding:     EncodingJSON,
+	})
+	if err != nil {
+		t.Fatalf("NewE() error = %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		l.Info("a line long enough to fill the file quickly")
+	}
+
+	eventually(t, func() string {
+		matches, _ := filepath.Glob(filepath.Join(dir, "*.service.log*"))
+		var compressed int
+		for _, path := range matches {
+			if strings.HasPrefix(filepath.Base(path), "2020-") {
+				return "old backup kept: " + path
+			}
+			if strings.HasSuffix(path, ".gz") {
+				compressed++
+			}
+		}
+		if compressed == 0 {
+			return "files = " + strings.Join(matches, ", ")
+		}
+		return ""
+	})
+}
+
+func TestLogger_RotateKeepsOtherFiles(t *testing.T) {
+	defer func(unit int64) { megabyte = unit }(megabyte)
+	megabyte = 1
+
+	dir := t.TempDir()
+	other := filepath.Join(dir, "error.log")
+	if err := os.WriteFile(other, []byte("error sink"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// FileName defaults to "log", the TDR file and error.log end with .log as well
+	l, err := NewE(Options{
+		FileLocation:    dir + "/",
+		FileMaxSize:     1,
+		FileMaxBackups:  1,
+		FileTdrLocation: filepath.Join(dir, "tdr.log"),
+		Encoding:        EncodingJSON,
+	})
+	if err != nil {
+		t.Fatalf("NewE() error = %v", err)
+	}
+	l.InfoTdr("", zap.String("threadID", "1"))
+	for i := 0; i < 10; i++ {
+		l.Info("a line long enough to rotate the file")
+	}
+
+	eventually(t, func() string {
+		current, _ := filepath.Glob(filepath.Join(dir, "*-*-*.log"))
+		backups, _ := filepath.Glob(filepath.Join(dir, "*-*-*.log.*"))
+		// the current file, one backup and the TDR file
+		if matches := append(current, backups...); len(matches) > 3 {
+			return "backups not trimmed: " + strings.Join(matches, ", ")
+		}
+		return ""
+	})
+	if tdr, _ := filepath.Glob(filepath.Join(dir, "*.tdr.log")); len(tdr) != 1 {
+		t.Errorf("tdr files = %v, want the live file kept", tdr)
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Errorf("error.log removed: %v", err)
+	}
+}
+
+func TestNewE_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+	}{
+		{"unknown sink", Options{Sinks: []SinkOption{{Type: "kafka"}}}},
+		{"unknown level", Options{Stdout: true, Level: "verbose"}},
+		{"unknown sink level", Options{Sinks: []SinkOption{{Type: SinkStdout, Level: "eror"}}}},
+		{"publish without url", Options{Stdout: true, PublishLog: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewE(tt.options); err == nil {
+				t.Errorf("NewE() error = nil, want error")
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New() did not panic")
+		}
+	}()
+	New(tests[0].options)
+}
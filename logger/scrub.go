@@ -0,0 +1,286 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Built-in detectors of ScrubOption.
+const (
+	// ScrubBearer redacts the token of "Bearer <token>".
+	ScrubBearer = "bearer"
+	// ScrubBasicAuth redacts the credentials of "Basic <base64>".
+	ScrubBasicAuth = "basic"
+	// ScrubJWT redacts JSON Web Tokens anywhere in the text.
+	ScrubJWT = "jwt"
+	// ScrubCard masks 13 to 19 digit card numbers passing the Luhn check like MaskPAN.
+	ScrubCard = "card"
+	// ScrubURLPassword redacts the password of user:password@host and of password,
+	// passwd, pwd or pass query parameters.
+	ScrubURLPassword = "url_password"
+)
+
+// DefaultScrubSkipKeys hold identifiers, such as the 16 digit request id, and are never scrubbed.
+var DefaultScrubSkipKeys = []string{"request_id", "personal_id", "threadID"}
+
+// ScrubOption removes secrets from messages and fields before they are encoded.
+type ScrubOption struct {
+	// Detectors are built-in detector names, all of them when empty.
+	Detectors []string `json:"detectors"`
+	// Patterns are additional detectors.
+	Patterns []ScrubPattern `json:"patterns"`
+	// SkipKeys are fields and object keys never scrubbed, in addition to DefaultScrubSkipKeys.
+	SkipKeys []string `json:"skipKeys"`
+}
+
+// ScrubPattern replaces the matches of Pattern with Replacement, which may refer to
+// submatches like regexp.Regexp.ReplaceAllString. Replacement defaults to [REDACTED].
+type ScrubPattern struct {
+	Name        string `json:"name"`
+	Pattern     string `json:"pattern"`
+	Replacement string `json:"replacement"`
+}
+
+type detector struct {
+	re      *regexp.Regexp
+	replace func(re *regexp.Regexp, s string) string
+}
+
+func replaceWith(replacement string) func(*regexp.Regexp, string) string {
+	return func(re *regexp.Regexp, s string) string {
+		return re.ReplaceAllString(s, replacement)
+	}
+}
+
+var builtinDetectors = map[string][]detector{
+	ScrubBearer: {{
+		re:      regexp.MustCompile(`\b((?i:bearer)\s+)[A-Za-z0-9\-._~+/]{8,}=*`),
+		replace: replaceWith("${1}" + redacted),
+	}},
+	ScrubBasicAuth: {{
+		// base64 of user:password, so words such as "basic information" stay
+		re:      regexp.MustCompile(`\b((?i:basic)\s+)(?:[A-Za-z0-9+/]{4}){2,}(?:[A-Za-z0-9+/]{2}==|[A-Za-z0-9+/]{3}=|\b)`),
+		replace: replaceWith("${1}" + redacted),
+	}},
+	ScrubJWT: {{
+		re:      regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+		replace: replaceWith(redacted),
+	}},
+	ScrubCard: {{
+		re: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		replace: func(re *regexp.Regexp, s string) string {
+			return re.ReplaceAllStringFunc(s, func(number string) string {
+				if !luhn(number) {
+					return number
+				}
+				return keepDigits(number, 6, 4)
+			})
+		},
+	}},
+	ScrubURLPassword: {
+		{
+			re:      regexp.MustCompile(`([A-Za-z][A-Za-z0-9+.-]*://[^:/?#\s@]+:)[^@/?#\s]+@`),
+			replace: replaceWith("${1}" + redacted + "@"),
+		},
+		{
+			re:      regexp.MustCompile(`(?i)([?&](?:password|passwd|pwd|pass)=)[^&#\s]+`),
+			replace: replaceWith("${1}" + redacted),
+		},
+	},
+}
+
+// the order detectors run in, JWTs before bearer tokens keeps the JWT message
+var builtinOrder = []string{ScrubJWT, ScrubBearer, ScrubBasicAuth, ScrubURLPassword, ScrubCard}
+
+// Scrubber replaces secrets found by its detectors.
+type Scrubber struct {
+	detectors []detector
+	skip      map[string]bool
+}
+
+// NewScrubber compiles option. It fails on an unknown detector or an invalid pattern.
+func NewScrubber(option ScrubOption) (*Scrubber, error) {
+	names := option.Detectors
+	if len(names) == 0 {
+		names = builtinOrder
+	}
+	s := &Scrubber{skip: map[string]bool{}}
+	for _, key := range append(append([]string(nil), DefaultScrubSkipKeys...), option.SkipKeys...) {
+		s.skip[key] = true
+	}
+	for _, name := range names {
+		detectors, ok := builtinDetectors[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("logger: scrub: unknown detector %s", name)
+		}
+		s.detectors = append(s.detectors, detectors...)
+	}
+	for _, pattern := range option.Patterns {
+		re, err := regexp.Compile(pattern.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("logger: scrub %s: %w", pattern.Name, err)
+		}
+		replacement := pattern.Replacement
+		if replacement == "" {
+			replacement = redacted
+		}
+		s.detectors = append(s.detectors, detector{re: re, replace: replaceWith(replacement)})
+	}
+	return s, nil
+}
+
+// Scrub returns text without the secrets found by the detectors.
+func (s *Scrubber) Scrub(text string) string {
+	for _, d := range s.detectors {
+		text = d.replace(d.re, text)
+	}
+	return text
+}
+
+// luhn validates the check digit of a card number, ignoring separators.
+func luhn(number string) bool {
+	sum, double := 0, false
+	for i := len(number) - 1; i >= 0; i-- {
+		r := rune(number[i])
+		if !unicode.IsDigit(r) {
+			continue
+		}
+		d := int(r - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// scrubCore scrubs the message and the fields of entries before the wrapped core
+// encodes them.
+type scrubCore struct {
+	zapcore.Core
+	scrubber *Scrubber
+}
+
+func (c *scrubCore) With(fields []zapcore.Field) zapcore.Core {
+	return &scrubCore{Core: c.Core.With(c.scrubber.fields(fields)), scrubber: c.scrubber}
+}
+
+func (c *scrubCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *scrubCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = c.scrubber.Scrub(ent.Message)
+	// the wrapped cores still apply their own levels, e.g. per sink
+	if ce := c.Core.Check(ent, nil); ce != nil {
+		ce.Write(c.scrubber.fields(fields)...)
+	}
+	return nil
+}
+
+// fields returns scrubbed fields, copying them only when one changes. Fields logged
+// with zap.Any, zap.Object or zap.Array have the strings they encode scrubbed.
+func (s *Scrubber) fields(fields []zapcore.Field) []zapcore.Field {
+	scrubbed, copied := fields, false
+	for i, f := range fields {
+		if s.skip[f.Key] {
+			continue
+		}
+		var clean zapcore.Field
+		switch f.Type {
+		case zapcore.StringType, zapcore.ByteStringType, zapcore.ErrorType:
+			value := ""
+			switch v := f.Interface.(type) {
+			case []byte:
+				value = string(v)
+			case error:
+				value = v.Error()
+			default:
+				value = f.String
+			}
+			text := s.Scrub(value)
+			if text == value {
+				continue
+			}
+			clean = zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: text}
+		case zapcore.ReflectType, zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+			value, changed := s.value(f)
+			if !changed {
+				continue
+			}
+			clean = zap.Any(f.Key, value)
+		default:
+			continue
+		}
+		if !copied {
+			scrubbed, copied = append([]zapcore.Field(nil), fields...), true
+		}
+		scrubbed[i] = clean
+	}
+	return scrubbed
+}
+
+// value decodes what f encodes to JSON values and scrubs their strings. Changed is
+// false when nothing was scrubbed, or f cannot be encoded.
+func (s *Scrubber) value(f zapcore.Field) (value interface{}, changed bool) {
+	var data interface{} = f.Interface
+	if f.Type != zapcore.ReflectType {
+		enc := zapcore.NewMapObjectEncoder()
+		f.AddTo(enc)
+		var ok bool
+		if data, ok = enc.Fields[f.Key]; !ok {
+			return nil, false
+		}
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	// keeps & in URLs for the url_password detector
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		return nil, false
+	}
+	decoder := json.NewDecoder(&buf)
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, false
+	}
+	return s.walk(value)
+}
+
+func (s *Scrubber) walk(value interface{}) (interface{}, bool) {
+	changed := false
+	switch v := value.(type) {
+	case string:
+		text := s.Scrub(v)
+		return text, text != v
+	case map[string]interface{}:
+		for key, item := range v {
+			if s.skip[key] {
+				continue
+			}
+			if clean, ok := s.walk(item); ok {
+				v[key], changed = clean, true
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			if clean, ok := s.walk(item); ok {
+				v[i], changed = clean, true
+			}
+		}
+	}
+	return value, changed
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func buildCores(config Options, level zap.AtomicLevel) ([]zapcore.Core, error) {
+	sinks := config.Sinks
+	if len(sinks) == 0 {
+		sink := SinkOption{Type: SinkFile, FileLinkName: config.FileLinkName}
+		if config.Stdout {
+			sink.Type = SinkStdout
+		}
+		sinks = []SinkOption{sink}
+	}
+
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for i, sink := range sinks {
+		encoderOptions := config
+		if sink.Encoding != "" {
+			encoderOptions.Encoding = sink.Encoding
+		}
+		enabler, err := sinkLevel(level, sink.Level)
+		if err != nil {
+			return nil, fmt.Errorf("logger: sink %d (%s): %w", i, sink.Type, err)
+		}
+		core, err := sinkCore(config, sink, getEncoder(encoderOptions), enabler)
+		if err != nil {
+			return nil, fmt.Errorf("logger: sink %d (%s): %w", i, sink.Type, err)
+		}
+		cores = append(cores, core)
+	}
+	return cores, nil
+}
+
+func sinkCore(config Options, sink SinkOption, encoder zapcore.Encoder, enabler zapcore.LevelEnabler) (zapcore.Core, error) {
+	// syslog needs the level of each entry for its severity
+	if strings.ToLower(sink.Type) == SinkSyslog {
+		return syslogCore(sink, encoder, enabler)
+	}
+	writer, err := sinkWriter(config, sink)
+	if err != nil {
+		return nil, err
+	}
+	return zapcore.NewCore(encoder, writer, enabler), nil
+}
+
+func sinkWriter(config Options, sink SinkOption) (zapcore.WriteSyncer, error) {
+	switch strings.ToLower(sink.Type) {
+	case SinkStdout:
+		return zapcore.Lock(os.Stdout), nil
+	case SinkStderr:
+		return zapcore.Lock(os.Stderr), nil
+	case SinkFile, "":
+		fileConfig := config
+		if sink.FileLocation != "" {
+			fileConfig.FileLocation = sink.FileLocation
+		}
+		if sink.FileName != "" {
+			fileConfig.FileName = sink.FileName
+		}
+		if sink.FileMaxAge != 0 {
+			fileConfig.FileMaxAge = sink.FileMaxAge
+		}
+		// every file needs its own link, so the link is never inherited
+		fileConfig.FileLinkName = sink.FileLinkName
+		return getRotateWriter(fileConfig)
+	case SinkWriter:
+		if sink.Writer == nil {
+			return nil, fmt.Errorf("writer is nil")
+		}
+		// arbitrary writers are not safe for concurrent use
+		return zapcore.Lock(zapcore.AddSync(sink.Writer)), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type")
+	}
+}
+
+// sinkLevel enables an entry when both the logger level and the sink level allow it,
+// so runtime changes through SetLevel still apply to every sink.
+func sinkLevel(level zap.AtomicLevel, minimum string) (zapcore.LevelEnabler, error) {
+	if minimum == "" {
+		return level, nil
+	}
+	sinkMinimum, err := parseLevel(minimum)
+	if err != nil {
+		return nil, err
+	}
+	return zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return level.Enabled(l) && l >= sinkMinimum
+	}), nil
+}
@@ -0,0 +1,68 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"log/syslog"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func syslogCore(sink SinkOption, encoder zapcore.Encoder, enabler zapcore.LevelEnabler) (zapcore.Core, error) {
+	writer, err := syslog.Dial(sink.Network, sink.Address, syslog.LOG_INFO|syslog.LOG_LOCAL0, sink.Tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogSink{LevelEnabler: enabler, encoder: encoder, writer: writer}, nil
+}
+
+// syslogSink sends every entry with the syslog severity of its level.
+type syslogSink struct {
+	zapcore.LevelEnabler
+	encoder zapcore.Encoder
+	writer  *syslog.Writer
+}
+
+func (s *syslogSink) With(fields []zapcore.Field) zapcore.Core {
+	encoder := s.encoder.Clone()
+	for _, f := range fields {
+		f.AddTo(encoder)
+	}
+	return &syslogSink{LevelEnabler: s.LevelEnabler, encoder: encoder, writer: s.writer}
+}
+
+func (s *syslogSink) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if s.Enabled(ent.Level) {
+		return ce.AddCore(ent, s)
+	}
+	return ce
+}
+
+func (s *syslogSink) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := s.encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+	return syslogSeverity(s.writer, ent.Level)(buf.String())
+}
+
+func (s *syslogSink) Sync() error {
+	return nil
+}
+
+// syslogSeverity maps a zap level to the syslog.Writer method of its severity.
+func syslogSeverity(writer *syslog.Writer, level zapcore.Level) func(string) error {
+	switch {
+	case level >= zapcore.DPanicLevel:
+		return writer.Crit
+	case level == zapcore.ErrorLevel:
+		return writer.Err
+	case level == zapcore.WarnLevel:
+		return writer.Warning
+	case level == zapcore.InfoLevel:
+		return writer.Info
+	default:
+		return writer.Debug
+	}
+}
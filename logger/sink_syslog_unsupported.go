@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logger
+
+import (
+	"errors"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func syslogCore(sink SinkOption, encoder zapcore.Encoder, enabler zapcore.LevelEnabler) (zapcore.Core, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"bytes"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNew_Sinks(t *testing.T) {
+	var all, errorsOnly bytes.Buffer
+	l := New(Options{
+		Level:    "debug",
+		Encoding: EncodingJSON,
+		Sinks: []SinkOption{
+			{Type: SinkWriter, Writer: &all},
+			{Type: SinkWriter, Writer: &errorsOnly, Level: "error", Encoding: EncodingLogfmt},
+		},
+	})
+
+	l.Debug("debug line")
+	l.Info("info line", zap.String("request_id", "1"))
+	l.Error("error line")
+
+	if got := strings.Count(all.String(), "\n"); got != 3 {
+		t.Errorf("all sink lines = %v, want 3:\n%s", got, all.String())
+	}
+	if got := strings.Count(errorsOnly.String(), "\n"); got != 1 {
+		t.Errorf("error sink lines = %v, want 1:\n%s", got, errorsOnly.String())
+	}
+	if !strings.Contains(errorsOnly.String(), "level=ERROR") {
+		t.Errorf("error sink = %s, want logfmt ERROR line", errorsOnly.String())
+	}
+
+	// raising the logger level applies to every sink
+	_ = l.SetLevel("error")
+	l.Warn("warn line")
+	if got := strings.Count(all.String(), "\n"); got != 3 {
+		t.Errorf("all sink lines after SetLevel = %v, want 3", got)
+	}
+}
+
+func TestBuildCores_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		sink SinkOption
+	}{
+		{"unknown type", SinkOption{Type: "kafka"}},
+		{"nil writer", SinkOption{Type: SinkWriter}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildCores(Options{Sinks: []SinkOption{tt.sink}}, zap.NewAtomicLevel()); err == nil {
+				t.Errorf("buildCores() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestNew_SyslogSink(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("syslog is not supported")
+	}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer conn.Close()
+
+	l := New(Options{Sinks: []SinkOption{{Type: SinkSyslog, Network: "udp", Address: conn.LocalAddr().String(), Tag: "wallet"}}})
+	l.Info("hello syslog")
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	if got := string(buf[:n]); !strings.Contains(got, "wallet") || !strings.Contains(got, "hello syslog") {
+		t.Errorf("syslog message = %q, want tag and message", got)
+	}
+}
+
+func TestNew_SyslogSeverity(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("syslog is not supported")
+	}
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer conn.Close()
+
+	l := New(Options{Level: "debug", Sinks: []SinkOption{{Type: SinkSyslog, Network: "udp", Address: conn.LocalAddr().String(), Tag: "wallet"}}})
+	// LOG_LOCAL0 is facility 16, the priority is 16*8 plus the severity
+	tests := []struct {
+		level zapcore.Level
+		want  string
+	}{
+		{zapcore.DebugLevel, "<135>"},
+		{zapcore.InfoLevel, "<134>"},
+		{zapcore.WarnLevel, "<132>"},
+		{zapcore.ErrorLevel, "<131>"},
+		{zapcore.DPanicLevel, "<130>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			l.Log(tt.level, "severity")
+			buf := make([]byte, 1024)
+			_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+			n, _, err := conn.ReadFrom(buf)
+			if err != nil {
+				t.Fatalf("ReadFrom() error = %v", err)
+			}
+			if got := string(buf[:n]); !strings.HasPrefix(got, tt.want) {
+				t.Errorf("syslog message = %q, want priority %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_WriterSinkConcurrent(t *testing.T) {
+	var out bytes.Buffer
+	l := New(Options{Sinks: []SinkOption{{Type: SinkWriter, Writer: &out}}})
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				l.Info("concurrent")
+			}
+		}()
+	}
+	wg.Wait()
+	if got := strings.Count(out.String(), "concurrent"); got != 1000 {
+		t.Errorf("written = %v, want 1000", got)
+	}
+}
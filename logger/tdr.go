@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LogTdrModel is one transaction detail record, written once per transaction by InfoTdr.
+type LogTdrModel struct {
+	AppName        string      `json:"app"`
+	AppVersion     string      `json:"ver"`
+	IP             string      `json:"ip"`
+	Port           int         `json:"port"`
+	SrcIP          string      `json:"srcIP"`
+	RespTime       int64       `json:"rt"`
+	Method         string      `json:"method"`
+	Path           string      `json:"path"`
+	Header         interface{} `json:"header"`
+	Request        interface{} `json:"req"`
+	Response       interface{} `json:"resp"`
+	Error          string      `json:"error"`
+	ThreadID       string      `json:"threadID"`
+	AdditionalData interface{} `json:"addData"`
+}
+
+// MarshalLogObject writes the record with the same keys as its json tags,
+// use it with zap.Inline or zap.Object.
+func (m LogTdrModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("app", m.AppName)
+	enc.AddString("ver", m.AppVersion)
+	enc.AddString("ip", m.IP)
+	enc.AddInt("port", m.Port)
+	enc.AddString("srcIP", m.SrcIP)
+	enc.AddInt64("rt", m.RespTime)
+	enc.AddString("method", m.Method)
+	enc.AddString("path", m.Path)
+	if err := enc.AddReflected("header", m.Header); err != nil {
+		return err
+	}
+	if err := addData(enc, "req", m.Request); err != nil {
+		return err
+	}
+	if err := addData(enc, "resp", m.Response); err != nil {
+		return err
+	}
+	enc.AddString("error", m.Error)
+	enc.AddString("threadID", m.ThreadID)
+	return enc.AddReflected("addData", m.AdditionalData)
+}
+
+// addData encodes the object and array marshalers returned by MaskedData without reflection.
+func addData(enc zapcore.ObjectEncoder, key string, data interface{}) error {
+	if marshaler, ok := data.(zapcore.ObjectMarshaler); ok {
+		return enc.AddObject(key, marshaler)
+	}
+	if marshaler, ok := data.(zapcore.ArrayMarshaler); ok {
+		return enc.AddArray(key, marshaler)
+	}
+	return enc.AddReflected(key, data)
+}
+
+// newTdrLogger writes JSON records to their own rotating file. FileTdrLocation is the
+// full path of the file, e.g. logs/service-lite.log. Without it, and without publishing,
+// InfoTdr uses the system logger.
+func newTdrLogger(config Options, publish zapcore.Core) (*zap.Logger, error) {
+	var cores []zapcore.Core
+	if config.FileTdrLocation != "" {
+		dir, file := filepath.Split(config.FileTdrLocation)
+		tdrConfig := config
+		tdrConfig.FileLocation = dir
+		tdrConfig.FileName = file
+		tdrConfig.FileLinkName = ""
+		writer, err := getRotateWriter(tdrConfig)
+		if err != nil {
+			return nil, fmt.Errorf("logger: tdr: %w", err)
+		}
+		// records are always written, independent of the system log level
+		cores = append(cores, zapcore.NewCore(tdrEncoder(config), writer, zapcore.DebugLevel))
+	}
+	if publish != nil {
+		cores = append(cores, publish)
+	}
+	if len(cores) == 0 {
+		return nil, nil
+	}
+	return zap.New(zapcore.NewTee(cores...)), nil
+}
+
+func tdrEncoder(config Options) zapcore.Encoder {
+	return zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+		TimeKey:        "time",
+		MessageKey:     zapcore.OmitKey,
+		EncodeTime:     timeEncoder(config),
+		EncodeDuration: MillisDurationEncoder,
+		LineEnding:     zapcore.DefaultLineEnding,
+	})
+}
+
+// InfoTdr writes a transaction detail record.
+func (l *Logger) InfoTdr(message string, fields ...zap.Field) {
+	if l.loggerTdr == nil {
+		l.LogDepth(1, zapcore.InfoLevel, message, fields...)
+		return
+	}
+	l.loggerTdr.Info(message, fields...)
+}
+
+// Tdr writes model as a transaction detail record.
+func (l *Logger) Tdr(model LogTdrModel) {
+	if l.loggerTdr == nil {
+		l.LogDepth(1, zapcore.InfoLevel, "", zap.Inline(model))
+		return
+	}
+	l.loggerTdr.Info("", zap.Inline(model))
+}
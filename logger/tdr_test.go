@@ -0,0 +1,80 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// readTdr returns the records written to the rotated TDR file in dir.
+func readTdr(t *testing.T, dir string) []map[string]interface{} {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.service-lite.log"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("tdr files = %v, %v, want one file", files, err)
+	}
+	b, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	for decoder.More() {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatalf("decode tdr record: %v\n%s", err, b)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger_Tdr(t *testing.T) {
+	dir := t.TempDir()
+	l := New(Options{Stdout: true, FileTdrLocation: filepath.Join(dir, "service-lite.log"), FileMaxAge: 1})
+	l.Tdr(LogTdrModel{
+		AppName:  "wallet",
+		Port:     8080,
+		RespTime: 12,
+		Path:     "/v1/balance",
+		Request:  map[string]interface{}{"pin": "******"},
+		Error:    "timeout",
+		ThreadID: "t-1",
+	})
+
+	records := readTdr(t, dir)
+	if len(records) != 1 {
+		t.Fatalf("records = %v, want 1", len(records))
+	}
+	got := records[0]
+	want := map[string]interface{}{
+		"app":      "wallet",
+		"port":     float64(8080),
+		"rt":       float64(12),
+		"path":     "/v1/balance",
+		"error":    "timeout",
+		"threadID": "t-1",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("record[%s] = %v, want %v", k, got[k], v)
+		}
+	}
+	if req, _ := got["req"].(map[string]interface{}); req["pin"] != "******" {
+		t.Errorf("record[req] = %v, want masked pin", got["req"])
+	}
+	if _, ok := got["time"]; !ok {
+		t.Errorf("record has no time: %v", got)
+	}
+}
+
+func TestLogger_InfoTdr_WithoutFile(t *testing.T) {
+	l := New(Options{Stdout: true})
+	if l.loggerTdr != nil {
+		t.Fatalf("loggerTdr = %v, want nil without FileTdrLocation", l.loggerTdr)
+	}
+	// falls back to the system logger
+	l.Tdr(LogTdrModel{AppName: "wallet"})
+}
@@ -0,0 +1,175 @@
+package logger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	throttleBuckets       = 4096
+	defaultSamplingFirst  = 100
+	defaultThrottleWindow = time.Second
+)
+
+// DroppedStats counts the entries suppressed by sampling and rate limiting.
+type DroppedStats struct {
+	Sampled     uint64 `json:"sampled"`
+	RateLimited uint64 `json:"rateLimited"`
+}
+
+// window counts the entries of one key in the current interval, like the zap sampler.
+type window struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+func (w *window) inc(now time.Time, interval time.Duration) uint64 {
+	t := now.UnixNano()
+	resetAt := w.resetAt.Load()
+	if t > resetAt {
+		// the first entry after the interval starts a new window
+		if w.resetAt.CompareAndSwap(resetAt, t+interval.Nanoseconds()) {
+			w.count.Store(1)
+			return 1
+		}
+	}
+	return w.count.Add(1)
+}
+
+type throttle struct {
+	sampling    bool
+	first       uint64
+	thereafter  uint64
+	tick        time.Duration
+	limit       uint64
+	interval    time.Duration
+	samples     [throttleBuckets]window
+	limits      [throttleBuckets]window
+	sampled     atomic.Uint64
+	rateLimited atomic.Uint64
+}
+
+// throttleCore drops repeated entries before they reach the wrapped core. The key of an
+// entry is its level, caller and message along with the fields in throttleKeyFields,
+// which tell apart entries without a message, so it has to decide in Write rather than
+// in Check.
+type throttleCore struct {
+	zapcore.Core
+	throttle *throttle
+}
+
+func newThrottle(config Options) *throttle {
+	if config.Sampling == nil && config.RateLimit == nil {
+		return nil
+	}
+	t := &throttle{}
+	if s := config.Sampling; s != nil {
+		t.sampling = true
+		t.first = uint64(s.Initial)
+		if s.Initial <= 0 {
+			t.first = defaultSamplingFirst
+		}
+		if s.Thereafter > 0 {
+			t.thereafter = uint64(s.Thereafter)
+		}
+		t.tick = s.Tick * time.Millisecond
+		if t.tick <= 0 {
+			t.tick = defaultThrottleWindow
+		}
+	}
+	if r := config.RateLimit; r != nil && r.Limit > 0 {
+		t.limit = uint64(r.Limit)
+		t.interval = r.Interval * time.Millisecond
+		if t.interval <= 0 {
+			t.interval = defaultThrottleWindow
+		}
+	}
+	return t
+}
+
+func (c *throttleCore) With(fields []zapcore.Field) zapcore.Core {
+	return &throttleCore{Core: c.Core.With(fields), throttle: c.throttle}
+}
+
+func (c *throttleCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *throttleCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !c.throttle.allow(ent, fields) {
+		return nil
+	}
+	// the wrapped cores still apply their own levels, e.g. per sink
+	if ce := c.Core.Check(ent, nil); ce != nil {
+		ce.Write(fields...)
+	}
+	return nil
+}
+
+func (t *throttle) allow(ent zapcore.Entry, fields []zapcore.Field) bool {
+	// never suppress entries that stop the program
+	if ent.Level >= zapcore.DPanicLevel {
+		return true
+	}
+	bucket := throttleKey(ent, fields) % throttleBuckets
+	if t.sampling {
+		n := t.samples[bucket].inc(ent.Time, t.tick)
+		if n > t.first && (t.thereafter == 0 || (n-t.first)%t.thereafter != 0) {
+			t.sampled.Add(1)
+			return false
+		}
+	}
+	if t.limit > 0 {
+		if n := t.limits[bucket].inc(ent.Time, t.interval); n > t.limit {
+			t.rateLimited.Add(1)
+			return false
+		}
+	}
+	return true
+}
+
+// throttleKeyFields tell entries apart that log an empty message, such as LogRequestHttp.
+var throttleKeyFields = map[string]bool{"message": true, "url": true, "uri": true, "sql": true}
+
+func throttleKey(ent zapcore.Entry, fields []zapcore.Field) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte{byte(ent.Level)})
+	if ent.Caller.Defined {
+		_, _ = h.Write([]byte(ent.Caller.File))
+		_, _ = h.Write([]byte(strconv.Itoa(ent.Caller.Line)))
+	}
+	_, _ = h.Write([]byte(ent.Message))
+	for _, f := range fields {
+		if !throttleKeyFields[f.Key] {
+			continue
+		}
+		_, _ = h.Write([]byte(f.Key))
+		switch f.Type {
+		case zapcore.StringType:
+			_, _ = h.Write([]byte(f.String))
+		case zapcore.ReflectType:
+			_, _ = fmt.Fprint(h, f.Interface)
+		}
+	}
+	return h.Sum32()
+}
+
+// DroppedStats returns the number of entries suppressed so far, all zero when
+// neither Sampling nor RateLimit is configured.
+func (l *Logger) DroppedStats() DroppedStats {
+	if l.throttle == nil {
+		return DroppedStats{}
+	}
+	return DroppedStats{
+		Sampled:     l.throttle.sampled.Load(),
+		RateLimited: l.throttle.rateLimited.Load(),
+	}
+}
@@ -0,0 +1,88 @@
+package logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_Sampling(t *testing.T) {
+	var out bytes.Buffer
+	l := New(Options{
+		Sinks:    []SinkOption{{Type: SinkWriter, Writer: &out}},
+		Sampling: &SamplingOption{Initial: 2, Thereafter: 3, Tick: 60000},
+	})
+	for i := 0; i < 11; i++ {
+		l.Warn("upstream timeout")
+	}
+	l.Warn("another message")
+
+	// entries 1, 2, 5, 8 and 11 of the repeated message plus the other one
+	if got := strings.Count(out.String(), "upstream timeout"); got != 5 {
+		t.Errorf("written = %v, want 5", got)
+	}
+	if !strings.Contains(out.String(), "another message") {
+		t.Errorf("output = %s, want another message", out.String())
+	}
+	if got, want := l.DroppedStats(), (DroppedStats{Sampled: 6}); got != want {
+		t.Errorf("DroppedStats() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLogger_RateLimit(t *testing.T) {
+	var out bytes.Buffer
+	l := New(Options{
+		Encoding:  EncodingJSON,
+		Sinks:     []SinkOption{{Type: SinkWriter, Writer: &out}},
+		RateLimit: &RateLimitOption{Limit: 3, Interval: 50},
+	})
+	// entries without a message, like LogRequestHttp, are told apart by their url
+	for i := 0; i < 10; i++ {
+		l.Log(zapcore.ErrorLevel, "", zap.String("url", "/v1/balance"))
+		l.Log(zapcore.ErrorLevel, "", zap.String("url", "/v1/transfer"))
+	}
+	if got := strings.Count(out.String(), "\n"); got != 6 {
+		t.Errorf("written = %v, want 3 per message:\n%s", got, out.String())
+	}
+	if got, want := l.DroppedStats(), (DroppedStats{RateLimited: 14}); got != want {
+		t.Errorf("DroppedStats() = %+v, want %+v", got, want)
+	}
+
+	// a new interval starts counting again
+	time.Sleep(60 * time.Millisecond)
+	l.Log(zapcore.ErrorLevel, "", zap.String("url", "/v1/balance"))
+	if got := strings.Count(out.String(), "\n"); got != 7 {
+		t.Errorf("written after interval = %v, want 7", got)
+	}
+}
+
+func TestLogger_ThrottleKeepsSinkLevels(t *testing.T) {
+	var all, errorsOnly bytes.Buffer
+	l := New(Options{
+		Sinks: []SinkOption{
+			{Type: SinkWriter, Writer: &all},
+			{Type: SinkWriter, Writer: &errorsOnly, Level: "error"},
+		},
+		RateLimit: &RateLimitOption{Limit: 100},
+	})
+	l.Info("info line")
+	l.Error("error line")
+
+	if got := strings.Count(all.String(), "\n"); got != 2 {
+		t.Errorf("all sink lines = %v, want 2", got)
+	}
+	if got := strings.Count(errorsOnly.String(), "\n"); got != 1 {
+		t.Errorf("error sink lines = %v, want 1", got)
+	}
+}
+
+func TestLogger_DroppedStatsDisabled(t *testing.T) {
+	l := New(Options{Stdout: true})
+	if got := l.DroppedStats(); got != (DroppedStats{}) {
+		t.Errorf("DroppedStats() = %+v, want zero", got)
+	}
+}
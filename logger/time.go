@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultTimeZone and DefaultTimeFormat are used when Options leaves them empty.
+const (
+	DefaultTimeZone   = "Asia/Jakarta"
+	DefaultTimeFormat = "2006-01-02 15:04:05.999"
+)
+
+// timeFormats are the names accepted in Options.TimeFormat besides Go layouts.
+var timeFormats = map[string]string{
+	"rfc3339":     time.RFC3339,
+	"rfc3339nano": time.RFC3339Nano,
+	"iso8601":     "2006-01-02T15:04:05.000Z0700",
+}
+
+// fixedZones are used when tzdata is missing, e.g. in scratch or distroless images.
+// Indonesia has no daylight saving time, so a fixed offset is exact.
+var fixedZones = map[string]*time.Location{
+	"asia/jakarta":   time.FixedZone("WIB", 7*60*60),
+	"asia/pontianak": time.FixedZone("WIB", 7*60*60),
+	"asia/makassar":  time.FixedZone("WITA", 8*60*60),
+	"asia/jayapura":  time.FixedZone("WIT", 9*60*60),
+	"wib":            time.FixedZone("WIB", 7*60*60),
+	"wita":           time.FixedZone("WITA", 8*60*60),
+	"wit":            time.FixedZone("WIT", 9*60*60),
+}
+
+var locations sync.Map
+
+// LoadLocation resolves an IANA zone name, UTC or Local once and caches it.
+// Indonesian zones fall back to a fixed offset when tzdata is not installed; for
+// other zones import time/tzdata in the main package.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		name = DefaultTimeZone
+	}
+	if location, ok := locations.Load(name); ok {
+		return location.(*time.Location), nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		fixed, ok := fixedZones[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("logger: time zone %s: %w", name, err)
+		}
+		location = fixed
+	}
+	locations.Store(name, location)
+	return location, nil
+}
+
+// timeLayout returns the Go layout of Options.TimeFormat.
+func timeLayout(format string) string {
+	if format == "" {
+		return DefaultTimeFormat
+	}
+	if layout, ok := timeFormats[strings.ToLower(format)]; ok {
+		return layout
+	}
+	return format
+}
+
+// TimeEncoder writes times in the given zone and format, see Options.TimeZone and Options.TimeFormat.
+func TimeEncoder(timeZone, format string) (zapcore.TimeEncoder, error) {
+	location, err := LoadLocation(timeZone)
+	if err != nil {
+		return nil, err
+	}
+	layout := timeLayout(format)
+	return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(t.In(location).Format(layout))
+	}, nil
+}
+
+// timeEncoder is TimeEncoder for options already validated by NewE.
+func timeEncoder(options Options) zapcore.TimeEncoder {
+	encodeTime, err := TimeEncoder(options.TimeZone, options.TimeFormat)
+	if err != nil {
+		return TDRLogTimeEncoder
+	}
+	return encodeTime
+}
+
+// TDRLogTimeEncoder writes times in Asia/Jakarta with DefaultTimeFormat.
+func TDRLogTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+	location, _ := LoadLocation(DefaultTimeZone)
+	enc.AppendString(t.In(location).Format(DefaultTimeFormat))
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestTimeEncoder(t *testing.T) {
+	at := time.Date(2024, 3, 1, 1, 2, 3, 456000000, time.UTC)
+	tests := []struct {
+		name     string
+		timeZone string
+		format   string
+		want     string
+		wantErr  bool
+	}{
+		{"default", "", "", "2024-03-01 08:02:03.456", false},
+		{"utc rfc3339nano", "UTC", "RFC3339Nano", "2024-03-01T01:02:03.456Z", false},
+		{"iso8601", "Asia/Makassar", "ISO8601", "2024-03-01T09:02:03.456+0800", false},
+		{"go layout", "UTC", "02/01/2006 15:04", "01/03/2024 01:02", false},
+		{"fixed zone fallback", "WIT", "rfc3339", "2024-03-01T10:02:03+09:00", false},
+		{"unknown zone", "Mars/Olympus", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encodeTime, err := TimeEncoder(tt.timeZone, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TimeEncoder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got := primitive(func(ae zapcore.ArrayEncoder) { encodeTime(at, ae) })
+			if got != tt.want {
+				t.Errorf("encoded time = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLocation_Cached(t *testing.T) {
+	first, err := LoadLocation("Asia/Jayapura")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := LoadLocation("Asia/Jayapura")
+	if first != second {
+		t.Errorf("LoadLocation() returned a new location, want the cached one")
+	}
+}
+
+func TestNewE_TimeZone(t *testing.T) {
+	if _, err := NewE(Options{Stdout: true, TimeZone: "Mars/Olympus"}); err == nil {
+		t.Errorf("NewE() error = nil, want unknown time zone error")
+	}
+}
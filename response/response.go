@@ -0,0 +1,128 @@
+package response
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	Error "github.com/ewinjuman/go-lib/error"
+	Session "github.com/ewinjuman/go-lib/session"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Response is the JSON envelope written by Success and Failed.
+type Response struct {
+	Code         int                `json:"code"`
+	Status       string             `json:"status"`
+	Message      string             `json:"message"`
+	BusinessCode string             `json:"businessCode,omitempty"`
+	Data         interface{}        `json:"data,omitempty"`
+	Errors       []Error.FieldError `json:"errors,omitempty"`
+}
+
+// ErrorHandler can be used directly as fiber.Config.ErrorHandler.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	return Failed(c, err)
+}
+
+// Success writes data with code 200.
+func Success(c *fiber.Ctx, data interface{}, message ...string) error {
+	resp := Response{
+		Code:    Error.SuccessCode,
+		Status:  Error.SuccessStatus,
+		Message: Error.StatusMessage(Error.SuccessCode),
+		Data:    data,
+	}
+	if len(message) > 0 {
+		resp.Message = message[0]
+	}
+	if session := getSession(c); session != nil {
+		session.LogResponse(resp)
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// Failed converts err to an ApplicationError and writes it. fiber.Error keeps its code,
+// the message is localized from the session language or the Accept-Language header.
+func Failed(c *fiber.Ctx, err error) error {
+	appErr := parseError(err)
+	session := getSession(c)
+
+	resp := Response{
+		Code:         appErr.ErrorCode,
+		Status:       appErr.Status,
+		Message:      Error.Localize(appErr, language(c, session)),
+		BusinessCode: appErr.BusinessCode,
+	}
+	if validationErr, ok := Error.AsValidationError(err); ok {
+		resp.Errors = validationErr.Fields
+	}
+	if session != nil {
+		session.LogResponse(resp, appErr.Error())
+	}
+	return c.Status(httpStatus(appErr.ErrorCode)).JSON(resp)
+}
+
+// ProblemErrorHandler is like ErrorHandler but answers with application/problem+json.
+func ProblemErrorHandler(c *fiber.Ctx, err error) error {
+	return Problem(c, err)
+}
+
+// Problem writes err as an RFC 7807 problem document.
+func Problem(c *fiber.Ctx, err error) error {
+	appErr := parseError(err)
+	session := getSession(c)
+
+	var problem *Error.Problem
+	if validationErr, ok := Error.AsValidationError(err); ok {
+		problem = validationErr.Problem(c.OriginalURL())
+	} else {
+		problem = appErr.Problem(c.OriginalURL())
+	}
+	problem.Detail = Error.Localize(appErr, language(c, session))
+	if session != nil {
+		session.LogResponse(problem, appErr.Error())
+	}
+
+	body, errMarshal := json.Marshal(problem)
+	if errMarshal != nil {
+		return errMarshal
+	}
+	c.Set(fiber.HeaderContentType, Error.ProblemContentType)
+	return c.Status(problem.Status).Send(body)
+}
+
+func parseError(err error) *Error.ApplicationError {
+	if err == nil {
+		return &Error.ApplicationError{
+			ErrorCode: Error.UndefinedCode,
+			Status:    Error.UndefinedStatus,
+			Message:   Error.UndefinedMessage,
+		}
+	}
+	var fiberErr *fiber.Error
+	if _, ok := Error.As(err); !ok && errors.As(err, &fiberErr) {
+		return Error.NewError(fiberErr.Code, Error.FailedStatus, fiberErr.Message).(*Error.ApplicationError)
+	}
+	return Error.ParseError(err)
+}
+
+func language(c *fiber.Ctx, session *Session.Session) string {
+	if session != nil && session.Language != "" {
+		return session.Language
+	}
+	return Error.DefaultCatalog.MatchLanguage(c.Get(fiber.HeaderAcceptLanguage))
+}
+
+func getSession(c *fiber.Ctx) *Session.Session {
+	session, _ := c.Locals(Session.AppSession).(*Session.Session)
+	return session
+}
+
+// httpStatus falls back to 500 for application codes that are not valid HTTP status codes.
+func httpStatus(code int) int {
+	if code < 100 || code > 599 {
+		return http.StatusInternalServerError
+	}
+	return code
+}
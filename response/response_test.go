@@ -0,0 +1,132 @@
+package response
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	Error "github.com/ewinjuman/go-lib/error"
+	Logger "github.com/ewinjuman/go-lib/logger"
+	Session "github.com/ewinjuman/go-lib/session"
+	"github.com/gofiber/fiber/v2"
+)
+
+func newApp(handler fiber.Handler) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	log := Logger.New(Logger.Options{Stdout: true})
+	app.Use(func(c *fiber.Ctx) error {
+		session := Session.New(log).SetURL(c.Path()).SetMethod(c.Method())
+		c.Locals(Session.AppSession, session)
+		return c.Next()
+	})
+	app.Get("/", handler)
+	return app
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        fiber.Handler
+		acceptLanguage string
+		wantStatus     int
+		wantBody       string
+	}{
+		{
+			"application error",
+			func(c *fiber.Ctx) error { return Error.New(409, Error.FailedStatus, "duplicate account") },
+			"",
+			409,
+			`{"code":409,"status":"FAILED","message":"duplicate account"}`,
+		},
+		{
+			"fiber error",
+			func(c *fiber.Ctx) error { return fiber.ErrUnauthorized },
+			"",
+			401,
+			`{"code":401,"status":"FAILED","message":"Unauthorized"}`,
+		},
+		{
+			"localized status message",
+			func(c *fiber.Ctx) error { return Error.NewError(404, Error.FailedStatus) },
+			"id-ID,id;q=0.9",
+			404,
+			`{"code":404,"status":"FAILED","message":"Data Tidak Ditemukan"}`,
+		},
+		{
+			"validation error",
+			func(c *fiber.Ctx) error {
+				return Error.NewValidationError(Error.FieldError{Field: "pin", Rule: "required", Message: "pin is required"})
+			},
+			"",
+			400,
+			`{"code":400,"status":"FAILED","message":"pin is required","errors":[{"field":"pin","rule":"required","message":"pin is required"}]}`,
+		},
+		{
+			"plain error",
+			func(c *fiber.Ctx) error { return io.ErrUnexpectedEOF },
+			"",
+			500,
+			`{"code":500,"status":"FAILED","message":"unexpected EOF"}`,
+		},
+		{
+			"custom application code",
+			func(c *fiber.Ctx) error { return Error.New(4001, Error.FailedStatus, "custom") },
+			"",
+			500,
+			`{"code":4001,"status":"FAILED","message":"custom"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set(fiber.HeaderAcceptLanguage, tt.acceptLanguage)
+			}
+			resp, err := newApp(tt.handler).Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, _ := io.ReadAll(resp.Body)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %v, want %v", resp.StatusCode, tt.wantStatus)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestSuccess(t *testing.T) {
+	app := newApp(func(c *fiber.Ctx) error {
+		return Success(c, map[string]string{"name": "ewin"})
+	})
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	want := `{"code":200,"status":"SUCCESS","message":"OK","data":{"name":"ewin"}}`
+	if resp.StatusCode != 200 || string(body) != want {
+		t.Errorf("Success() = %v %s, want 200 %s", resp.StatusCode, body, want)
+	}
+}
+
+func TestProblemErrorHandler(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: ProblemErrorHandler})
+	app.Get("/accounts", func(c *fiber.Ctx) error {
+		return Error.New(409, Error.FailedStatus, "account already exists")
+	})
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/accounts", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	want := `{"applicationStatus":"FAILED","detail":"account already exists","errorCode":409,"instance":"/accounts","status":409,"title":"Conflict","type":"about:blank"}`
+	if resp.StatusCode != 409 || string(body) != want {
+		t.Errorf("ProblemErrorHandler() = %v %s, want 409 %s", resp.StatusCode, body, want)
+	}
+	if got := resp.Header.Get(fiber.HeaderContentType); got != Error.ProblemContentType {
+		t.Errorf("Content-Type = %v, want %v", got, Error.ProblemContentType)
+	}
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"runtime"
@@ -14,6 +15,7 @@ import (
 	Map "github.com/orcaman/concurrent-map"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 const (
@@ -35,6 +37,7 @@ type Session struct {
 	ErrorMessage            string
 	ActionTo                string
 	ActionName              string
+	Language                string
 }
 
 func New(logger *Logger.Logger) *Session {
@@ -45,17 +48,23 @@ func New(logger *Logger.Logger) *Session {
 		Logger:      logger,
 		Map:         Map.New(),
 	}
-	session.ThreadID = sessionID
-	session.Logger.ThreadID = sessionID
-	return session
+	return session.SetThreadID(sessionID)
 }
 
+// SetThreadID gives the session its own child Logger, so the shared Logger passed
+// to New is never changed by a request.
 func (session *Session) SetThreadID(sessionID string) *Session {
 	session.ThreadID = sessionID
+	session.Logger = session.Logger.With()
 	session.Logger.ThreadID = sessionID
 	return session
 }
 
+// Context returns a copy of ctx carrying the session Logger, read it back with logger.FromContext.
+func (session *Session) Context(ctx context.Context) context.Context {
+	return Logger.NewContext(ctx, session.Logger)
+}
+
 func (session *Session) SetMethod(method string) *Session {
 	session.Method = method
 	return session
@@ -121,6 +130,11 @@ func (session *Session) SetActionName(actionName string) *Session {
 	return session
 }
 
+func (session *Session) SetLanguage(language string) *Session {
+	session.Language = language
+	return session
+}
+
 func (session *Session) SetPersonalIdentifier(phone string) *Session {
 	session.PersonalId = phone
 	return session
@@ -139,7 +153,7 @@ func (session *Session) Put(key string, data interface{}) {
 }
 
 func (session *Session) LogDatabase(sql string, result interface{}, error interface{}) {
-	session.Logger.InfoSys("",
+	session.Logger.LogDepth(1, zapcore.InfoLevel, "",
 		zap.String("request_id", session.ThreadID),
 		zap.String("sql", sql),
 		zap.Any("result", result),
@@ -152,210 +166,197 @@ func (session *Session) LogRequest(message ...interface{}) {
 	//	req := session.NewPublishLog().Request().SetInfo().SetRequestBody(session.Request).SetRequestHeader(session.Header)
 	//	go session.Logger.PublishLog(req)
 	//}
-	if session.Request != nil {
-		session.Request = session.Logger.MaskingJson(session.Request)
-	}
-	session.Logger.InfoSys("",
-		zap.String("level", "INFO"),
+	session.Logger.LogDepth(1, zapcore.InfoLevel, formatResponse(message...),
 		zap.String("request_id", session.ThreadID),
 		zap.String("method", session.Method),
 		zap.String("url", session.URL),
-		zap.Any("request", session.Request),
-		zap.Any("header", session.Header),
-		zap.String("message", formatResponse(message...)),
+		session.Logger.Masked("request", session.Request),
+		zap.Any("header", session.Logger.Headers(session.Header)),
 	)
 }
 
 func (session *Session) LogResponse(response interface{}, message ...interface{}) {
-	if response != nil {
-		response = session.Logger.MaskingJson(response)
-	}
 	stop := time.Now()
 	rt := stop.Sub(session.RequestTime).Milliseconds()
-	session.Logger.InfoSys("",
-		zap.String("level", "INFO"),
+	session.Logger.LogDepth(1, zapcore.InfoLevel, formatResponse(message...),
 		zap.String("request_id", session.ThreadID),
 		zap.Any("personal_id", session.PersonalId),
 		zap.String("method", session.Method),
 		zap.String("url", session.URL),
-		zap.Any("response", response),
+		session.Logger.Masked("response", response),
 		zap.String("response_time", fmt.Sprintf("%d ms", rt)),
-		zap.String("message", formatResponse(message...)),
 	)
 }
 
 func (session *Session) LogRequestHttp(url string, method string, body interface{}, header interface{}, params interface{}) {
-	if body != nil {
-		//b, _ := json.Marshal(body)
-		body = session.Logger.MaskingJson(body)
-	}
-	session.Logger.InfoSys("",
-		zap.String("level", "INFO"),
+	session.Logger.LogDepth(1, zapcore.InfoLevel, "",
 		zap.String("request_id", session.ThreadID),
 		zap.String("personal_id", session.PersonalId),
 		zap.String("method", method),
 		zap.String("url", url),
-		zap.Any("request", body),
+		session.Logger.Masked("request", body),
 		zap.Any("params", params),
-		zap.Any("header", header),
+		zap.Any("header", session.Logger.Headers(header)),
 	)
 }
 
 func (session *Session) LogResponseHttp(responseTime time.Duration, code int, url string, method string, body interface{}, messageError ...string) {
-	if body != nil {
-		//b, _ := json.Marshal(body)
-		body = session.Logger.MaskingJson(body)
-	}
-
 	if len(messageError) > 0 {
 		msgErr := ""
 		msgErr = strings.Join(messageError, ",")
-		session.Logger.InfoSys("",
-			zap.String("level", "INFO"),
+		session.Logger.LogDepth(1, zapcore.InfoLevel, "",
 			zap.String("request_id", session.ThreadID),
 			zap.String("personal_id", session.PersonalId),
 			zap.String("method", method),
 			zap.String("url", url),
 			zap.Int("http_status", code),
 			zap.String("error", msgErr),
-			zap.Any("response", body),
+			session.Logger.Masked("response", body),
 			zap.String("process_time", fmt.Sprintf("%d ms", responseTime.Milliseconds())),
 		)
 	} else {
-		session.Logger.InfoSys("",
-			zap.String("level", "INFO"),
+		session.Logger.LogDepth(1, zapcore.InfoLevel, "",
 			zap.String("request_id", session.ThreadID),
 			zap.String("personal_id", session.PersonalId),
 			zap.String("method", method),
 			zap.String("url", url),
 			zap.Int("http_status", code),
-			zap.Any("response", body),
+			session.Logger.Masked("response", body),
 			zap.String("process_time", fmt.Sprintf("%d ms", responseTime.Milliseconds())),
 		)
 	}
 }
 
 func (session *Session) LogRequestGrpc(url string, method string, body interface{}, header interface{}) {
-
-	if body != nil {
-		//b, _ := json.Marshal(body)
-		body = session.Logger.MaskingJson(body)
-	}
-	session.Logger.InfoSys("",
-		zap.String("level", "INFO"),
+	session.Logger.LogDepth(1, zapcore.InfoLevel, "",
 		zap.String("request_id", session.ThreadID),
 		zap.String("personal_id", session.PersonalId),
 		zap.String("method", method),
 		zap.String("url", url),
-		zap.Any("request", body),
-		zap.Any("header", header),
+		session.Logger.Masked("request", body),
+		zap.Any("header", session.Logger.Headers(header)),
 	)
 }
 
 func (session *Session) LogResponseGrpc(startProcessTime time.Time, url string, method string, body interface{}) {
 	stop := time.Now()
-	if body != nil {
-		//b, _ := json.Marshal(body)
-		body = session.Logger.MaskingJson(body)
-	}
-	session.Logger.InfoSys("",
-		zap.String("level", "INFO"),
+	session.Logger.LogDepth(1, zapcore.InfoLevel, "",
 		zap.String("request_id", session.ThreadID),
 		zap.String("personal_id", session.PersonalId),
 		zap.String("method", method),
 		zap.String("url", url),
-		zap.Any("response", body),
+		session.Logger.Masked("response", body),
 		zap.String("process_time", fmt.Sprintf("%d ms", stop.Sub(startProcessTime).Milliseconds())),
 	)
 }
 
 func (session *Session) LogMessage(message interface{}, data interface{}) {
-	session.Logger.InfoSys("",
+	session.Logger.LogDepth(1, zapcore.InfoLevel, formatResponse(message),
 		zap.String("request_id", session.ThreadID),
-		zap.Any("message", message),
 		zap.Any("data", data),
 	)
 }
 
-func (session *Session) LogTdr(message interface{}) {
+// LogTdr writes the transaction detail record of the session with response as its response.
+// Request and response are masked like LogRequest and LogResponse.
+func (session *Session) LogTdr(response interface{}) {
 	stop := time.Now()
 	rt := stop.Sub(session.RequestTime).Milliseconds()
 
-	session.Logger.InfoSys("",
-		zap.String("request_id", session.ThreadID),
-		zap.String("method", session.Method),
-		zap.String("uri", session.URL),
-		zap.Any("response", message),
-		zap.String("response_time", fmt.Sprintf("%d ms", rt)),
-	)
+	session.Logger.Tdr(Logger.LogTdrModel{
+		AppName:    session.AppName,
+		AppVersion: session.AppVersion,
+		IP:         session.IP,
+		Port:       session.Port,
+		SrcIP:      session.SrcIP,
+		RespTime:   rt,
+		Method:     session.Method,
+		Path:       session.URL,
+		Header:     session.Logger.Headers(session.Header),
+		Request:    session.Logger.MaskedData(session.Request),
+		Response:   session.Logger.MaskedData(response),
+		Error:      session.ErrorMessage,
+		ThreadID:   session.ThreadID,
+	})
+}
 
-	//session.Logger.InfoTdr("",
-	//	zap.String("request_id", session.ThreadID),
-	//	zap.String("method", session.Method),
-	//	zap.String("uri", session.URL),
-	//	zap.Any("request", session.Request),
-	//	zap.Any("response", message),
-	//	zap.String("response_time", fmt.Sprintf("%d ms", rt)),
-	//	zap.Any("header_request", session.Header),
-	//)
+func (session *Session) Debug(message ...interface{}) {
+	session.log(zapcore.DebugLevel, message...)
 }
 
 func (session *Session) Info(message ...interface{}) {
+	session.log(zapcore.InfoLevel, message...)
+}
+
+func (session *Session) Warn(message ...interface{}) {
+	session.log(zapcore.WarnLevel, message...)
+}
+
+func (session *Session) Error(message interface{}) {
+	session.logError(zapcore.ErrorLevel, message)
+}
+
+// Fatal logs like Error and then calls os.Exit(1).
+func (session *Session) Fatal(message interface{}) {
+	session.logError(zapcore.FatalLevel, message)
+}
+
+func (session *Session) log(level zapcore.Level, message ...interface{}) {
 	stop := time.Now()
 	rt := stop.Sub(session.RequestTime).Milliseconds()
 
-	session.Logger.InfoSys("",
-		zap.String("level", "INFO"),
+	session.Logger.LogDepth(2, level, formatResponse(message...),
 		zap.String("request_id", session.ThreadID),
 		zap.String("method", session.Method),
 		zap.String("uri", session.URL),
-		zap.Any("message", message),
 		zap.String("response_time", fmt.Sprintf("%d ms", rt)),
 	)
-
-	//session.Logger.InfoTdr("",
-	//	zap.String("level", "INFO"),
-	//	zap.String("request_id", session.ThreadID),
-	//	zap.String("method", session.Method),
-	//	zap.String("uri", session.URL),
-	//	zap.Any("request", session.Request),
-	//	zap.Any("response", message),
-	//	zap.String("response_time", fmt.Sprintf("%d ms", rt)),
-	//	zap.Any("header_request", session.Header),
-	//)
 }
 
-func (session *Session) Error(message interface{}) {
+func (session *Session) logError(level zapcore.Level, message interface{}) {
 	stop := time.Now()
 	rt := stop.Sub(session.RequestTime).Milliseconds()
-	_, fn, line, _ := runtime.Caller(1)
+	// skip logError and Error/Fatal
+	_, fn, line, _ := runtime.Caller(2)
 	file := fmt.Sprintf("%s:%d", fn, line)
 
-	session.Logger.InfoSys("",
-		zap.String("level", "ERROR"),
+	// the text is the entry message, a message field would repeat the message key
+	text := formatResponse(message)
+	var stack errors.StackTrace
+	if err, ok := message.(error); ok {
+		text = err.Error()
+		stack = stackTrace(err)
+	}
+	fields := []zap.Field{
 		zap.String("request_id", session.ThreadID),
 		zap.String("personal_id", session.PersonalId),
 		zap.String("method", session.Method),
 		zap.String("uri", session.URL),
-		zap.Any("message", message),
 		zap.String("file", file),
 		zap.String("response_time", fmt.Sprintf("%d ms", rt)),
-	)
-
-	//session.Logger.InfoTdr("",
-	//	zap.String("level", "ERROR"),
-	//	zap.String("request_id", session.ThreadID),
-	//	zap.String("method", session.Method),
-	//	zap.String("uri", session.URL),
-	//	zap.Any("request", session.Request),
-	//	zap.Any("response", message),
-	//	zap.String("response_time", fmt.Sprintf("%d ms", rt)),
-	//	zap.Any("header_request", session.Header),
-	//)
+	}
+	if stack != nil {
+		fields = append(fields, zap.String("stacktrace", fmt.Sprintf("%+v", stack)))
+	}
+	session.Logger.LogDepth(2, level, text, fields...)
 }
 
 var json = JsonIter.ConfigCompatibleWithStandardLibrary
 
+// stackTrace returns the stack of the first error in the chain that recorded one,
+// such as an ApplicationError created while stack capture is enabled.
+func stackTrace(err error) errors.StackTrace {
+	for err != nil {
+		if tracer, ok := err.(interface{ StackTrace() errors.StackTrace }); ok {
+			if stack := tracer.StackTrace(); stack != nil {
+				return stack
+			}
+		}
+		err = errors.Unwrap(err)
+	}
+	return nil
+}
+
 func formatResponse(message ...interface{}) string {
 	sb := strings.Builder{}
 